@@ -0,0 +1,29 @@
+package core
+
+import "github.com/sclevine/agouti/core/internal/types"
+
+// Capabilities is a builder for the capabilities requested when creating a Page.
+// Example:
+//
+//	capabilities := core.NewCapabilities().Browser("chrome").Require("browserName").
+//	    ChromeOptions(core.ChromeOptions{Args: []string{"--headless"}})
+//	page, err := browser.PageWithCapabilities(capabilities)
+//
+// Capabilities are sent in both the JSON Wire and W3C formats, so that JSON Wire
+// names (such as "version" or "chromeOptions") work with either kind of WebDriver.
+type Capabilities = types.Capabilities
+
+// ChromeOptions configures ChromeDriver through the chromeOptions capability,
+// or msedgedriver through the ms:edgeOptions capability.
+type ChromeOptions = types.ChromeOptions
+
+// FirefoxOptions configures geckodriver through the moz:firefoxOptions capability.
+type FirefoxOptions = types.FirefoxOptions
+
+// Proxy configures the proxy used by the browser.
+type Proxy = types.Proxy
+
+// NewCapabilities returns an empty set of capabilities.
+func NewCapabilities() *Capabilities {
+	return types.NewCapabilities()
+}
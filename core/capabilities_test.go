@@ -0,0 +1,36 @@
+package core_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/core"
+)
+
+var _ = Describe("Capabilities", func() {
+	Context("when not created with NewCapabilities", func() {
+		It("adds desired capabilities", func() {
+			capabilities := (&Capabilities{}).Browser("firefox")
+			Expect(capabilities.Desired).To(Equal(map[string]interface{}{"browserName": "firefox"}))
+		})
+
+		It("requires desired capabilities", func() {
+			capabilities := (&Capabilities{}).Browser("firefox").Require("browserName")
+			Expect(capabilities.Desired).To(BeEmpty())
+			Expect(capabilities.Required).To(Equal(map[string]interface{}{"browserName": "firefox"}))
+		})
+	})
+
+	Describe("#Require", func() {
+		It("keeps the required value when the capability is desired afterwards", func() {
+			capabilities := NewCapabilities().Browser("firefox").Require("browserName").Browser("chrome")
+			Expect(capabilities.Desired).To(BeEmpty())
+			Expect(capabilities.Required).To(Equal(map[string]interface{}{"browserName": "firefox"}))
+		})
+
+		It("requires the latest desired value when the capability is desired beforehand", func() {
+			capabilities := NewCapabilities().Browser("chrome").Browser("firefox").Require("browserName")
+			Expect(capabilities.Desired).To(BeEmpty())
+			Expect(capabilities.Required).To(Equal(map[string]interface{}{"browserName": "firefox"}))
+		})
+	})
+})
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"github.com/sclevine/agouti/core/internal/page"
+	"github.com/sclevine/agouti/core/internal/types"
+	"net/http"
+)
+
+// Cookie is a browser cookie. Use HTTPCookie to convert it to a *http.Cookie.
+type Cookie = types.Cookie
+
+// CookieFromHTTP converts a *http.Cookie to a Cookie that may be added to a Page.
+func CookieFromHTTP(cookie *http.Cookie) *Cookie {
+	return types.CookieFromHTTP(cookie)
+}
+
+// NewCookieJar returns an http.CookieJar that reads and writes the cookies of browserPage,
+// so that an http.Client can share a session with the browser.
+// The page must be on the domain of the requested URL, as a browser only
+// exposes and accepts cookies for the domain it is currently on.
+func NewCookieJar(browserPage Page) http.CookieJar {
+	return &page.CookieJar{Page: browserPage}
+}
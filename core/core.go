@@ -2,80 +2,205 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/sclevine/agouti/core/internal/browser"
+	"github.com/sclevine/agouti/core/internal/page"
 	"github.com/sclevine/agouti/core/internal/service"
+	"github.com/sclevine/agouti/core/internal/session"
 	"github.com/sclevine/agouti/core/internal/types"
-	"net"
+	"github.com/sclevine/agouti/core/internal/webdriver"
+	neturl "net/url"
 	"strings"
-	"time"
 )
 
-type Selection types.Selection
+// Selection is an alias, so that selections may be passed to Page methods
+// such as Screenshot, which accept selections to highlight.
+type Selection = types.Selection
+
 type Page types.Page
 
-// Browser represents a Selenium, PhantomJS, or Chrome (via ChromeDriver) WebDriver process
+// Browser represents a Selenium, PhantomJS, Chrome (via ChromeDriver), Firefox (via geckodriver)
+// or Edge (via msedgedriver) WebDriver process,
+// or a connection to a remote WebDriver
 type Browser interface {
 	// Start launches the WebDriver process
 	Start() error
 
+	// StartContext launches the WebDriver process, giving up when ctx is done
+	StartContext(ctx context.Context) error
+
 	// Stop ends all sessions and stops the WebDriver process
 	Stop() (nonFatal error)
 
+	// URL returns the URL of the WebDriver. For a WebDriver process, the URL
+	// includes the port it was started on and is only known after Start.
+	URL() string
+
 	// Page returns a new WebDriver session.
 	// For Selenium, browserName is the type of browser ("firefox", "safari", "chrome", etc.)
 	Page(browserName ...string) (types.Page, error)
+
+	// PageContext returns a new WebDriver session, giving up when ctx is done.
+	// The context only applies to creating the session; use Page.WithContext to scope later requests.
+	PageContext(ctx context.Context, browserName ...string) (types.Page, error)
+
+	// PageWithCapabilities returns a new WebDriver session with the provided capabilities.
+	// The capabilities negotiated by the WebDriver are available through Page.Capabilities.
+	PageWithCapabilities(capabilities *Capabilities) (types.Page, error)
 }
 
 // Chrome returns an instance of a Chrome Browser via ChromeDriver
-func Chrome() (Browser, error) {
-	address, err := freeAddress()
-	if err != nil {
-		return nil, fmt.Errorf("failed to locate a free port: %s", err)
+func Chrome(options ...Option) (Browser, error) {
+	config := newConfig(options)
+	command := []string{"chromedriver", "--port={{port}}"}
+	if config.verbose {
+		command = append(command, "--verbose")
+	} else {
+		command = append(command, "--silent")
+	}
+	if config.driverLogPath != "" {
+		command = append(command, "--log-path="+config.driverLogPath)
 	}
 
-	port := strings.SplitN(address, ":", 2)[1]
-	url := fmt.Sprintf("http://%s", address)
-	command := []string{"chromedriver", "--silent", "--port=" + port}
-	service := &service.Service{URL: url, Timeout: 5 * time.Second, Command: command}
-
-	return &browser.Browser{Service: service}, nil
+	return newBrowser(config, "http://{{address}}", command), nil
 }
 
 // PhantomJS returns an instance of a PhantomJS Browser
-func PhantomJS() (Browser, error) {
-	address, err := freeAddress()
-	if err != nil {
-		return nil, fmt.Errorf("failed to locate a free port: %s", err)
+func PhantomJS(options ...Option) (Browser, error) {
+	config := newConfig(options)
+	command := []string{"phantomjs", "--webdriver={{address}}"}
+	if config.verbose {
+		command = append(command, "--webdriver-loglevel=DEBUG")
+	}
+	if config.driverLogPath != "" {
+		command = append(command, "--webdriver-logfile="+config.driverLogPath)
 	}
 
-	url := fmt.Sprintf("http://%s", address)
-	command := []string{"phantomjs", fmt.Sprintf("--webdriver=%s", address)}
-	service := &service.Service{URL: url, Timeout: 5 * time.Second, Command: command}
+	return newBrowser(config, "http://{{address}}", command), nil
+}
+
+// Firefox returns an instance of a Firefox Browser via geckodriver.
+// Geckodriver supports a single session at a time, so only one Page may be open at once.
+// Verbose enables trace logging. Geckodriver logs to its output rather than to a file,
+// so DriverLog appends its output to the file at the provided path, and cannot be
+// combined with Output or with an OutputFile at another path.
+func Firefox(options ...Option) (Browser, error) {
+	config := newConfig(options)
+	command := []string{"geckodriver", "--host={{host}}", "--port={{port}}"}
+	if config.verbose {
+		command = append(command, "-vv")
+	}
+	if config.driverLogPath != "" {
+		if config.output != nil || (config.outputPath != "" && config.outputPath != config.driverLogPath) {
+			return nil, errors.New("geckodriver logs to its output, so DriverLog cannot be combined with Output or OutputFile")
+		}
+		config.outputPath = config.driverLogPath
+	}
+
+	return newBrowser(config, "http://{{address}}", command), nil
+}
+
+// Edge returns an instance of a Microsoft Edge Browser via msedgedriver
+func Edge(options ...Option) (Browser, error) {
+	config := newConfig(options)
+	command := []string{"msedgedriver", "--port={{port}}"}
+	if config.verbose {
+		command = append(command, "--verbose")
+	} else {
+		command = append(command, "--silent")
+	}
+	if config.driverLogPath != "" {
+		command = append(command, "--log-path="+config.driverLogPath)
+	}
 
-	return &browser.Browser{Service: service}, nil
+	return newBrowser(config, "http://{{address}}", command), nil
 }
 
 // Selenium returns an instance of a Selenium Browser
-func Selenium() (Browser, error) {
-	address, err := freeAddress()
-	if err != nil {
-		return nil, fmt.Errorf("failed to locate a free port: %s", err)
+func Selenium(options ...Option) (Browser, error) {
+	config := newConfig(append([]Option{URLPrefix("/wd/hub")}, options...))
+	command := []string{"selenium-server", "-port", "{{port}}"}
+	if config.verbose {
+		command = append(command, "-debug")
+	}
+	if config.driverLogPath != "" {
+		command = append(command, "-log", config.driverLogPath)
+	}
+
+	return newBrowser(config, "http://{{address}}", command), nil
+}
+
+// NewBrowser returns a Browser that runs any WebDriver-compatible server.
+// The {{address}}, {{host}} and {{port}} placeholders in the command and
+// urlTemplate are replaced with a free local address when the Browser is started.
+// For example:
+//
+//	core.NewBrowser([]string{"geckodriver", "--port={{port}}"}, "http://{{address}}")
+func NewBrowser(command []string, urlTemplate string, options ...Option) (Browser, error) {
+	if len(command) == 0 {
+		return nil, errors.New("a command is required")
+	}
+
+	exampleURL := strings.NewReplacer("{{address}}", "127.0.0.1:0", "{{host}}", "127.0.0.1", "{{port}}", "0").Replace(urlTemplate)
+	if _, err := neturl.Parse(exampleURL); err != nil {
+		return nil, fmt.Errorf("invalid WebDriver URL: %s", err)
+	}
+
+	command = append([]string(nil), command...)
+	return newBrowser(newConfig(options), strings.TrimSuffix(urlTemplate, "/"), command), nil
+}
+
+func newBrowser(config *config, url string, command []string) Browser {
+	if config.binary != "" {
+		command[0] = config.binary
 	}
 
-	port := strings.SplitN(address, ":", 2)[1]
-	url := fmt.Sprintf("http://%s/wd/hub", address)
-	command := []string{"selenium-server", "-port", port}
-	service := &service.Service{URL: url, Timeout: 5 * time.Second, Command: command}
+	service := &service.Service{
+		URL:          url + config.urlPrefix,
+		Timeout:      config.startTimeout,
+		Command:      append(command, config.args...),
+		Client:       config.client(),
+		Output:       config.output,
+		OutputPath:   config.outputPath,
+		GracePeriod:  config.gracePeriod,
+		MaxRestarts:  config.maxRestarts,
+		Ports:        config.ports,
+		Env:          config.env,
+		Dir:          config.dir,
+		PollInterval: config.pollInterval,
+	}
+	return &browser.Browser{Service: service, Timeout: config.timeout, LoggingPrefs: config.loggingPrefs, PageTimeouts: config.pageTimeouts}
+}
 
-	return &browser.Browser{Service: service}, nil
+// Remote returns a Browser connected to an already-running WebDriver at url,
+// such as a Selenium grid (http://host:4444/wd/hub) or a running ChromeDriver.
+// Start and Stop do not manage any process; Stop only ends the sessions created by the Browser.
+func Remote(url string, options ...Option) (Browser, error) {
+	if _, err := neturl.Parse(url); err != nil {
+		return nil, fmt.Errorf("invalid WebDriver URL: %s", err)
+	}
+
+	config := newConfig(options)
+	service := &service.Remote{
+		URL:          strings.TrimSuffix(url, "/") + config.urlPrefix,
+		Timeout:      config.startTimeout,
+		PollInterval: config.pollInterval,
+		Client:       config.client(),
+	}
+	return &browser.Browser{Service: service, Timeout: config.timeout, LoggingPrefs: config.loggingPrefs, PageTimeouts: config.pageTimeouts}, nil
 }
 
-func freeAddress() (string, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+// AttachPage returns a Page for an existing session on the WebDriver at url.
+// The session is not ended when the Page is no longer used.
+func AttachPage(url, sessionID string, options ...Option) (Page, error) {
+	config := newConfig(options)
+	pageSession, err := session.Attach(context.Background(), config.client(), strings.TrimSuffix(url, "/"), sessionID)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to attach page: %w", err)
 	}
-	defer listener.Close()
-	return listener.Addr().String(), nil
+	pageSession.Timeout = config.timeout
+
+	return &page.Page{Driver: &webdriver.Driver{Session: pageSession}}, nil
 }
@@ -0,0 +1,54 @@
+package core_test
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/core"
+)
+
+var _ = Describe("Core", func() {
+	Describe(".NewBrowser", func() {
+		It("returns a browser for a URL template with an address placeholder", func() {
+			browser, err := NewBrowser([]string{"some-webdriver", "--port={{port}}"}, "http://{{address}}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(browser).NotTo(BeNil())
+		})
+
+		It("returns a browser for a URL template with host and port placeholders", func() {
+			_, err := NewBrowser([]string{"some-webdriver"}, "http://{{host}}:{{port}}/wd/hub")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("with an invalid URL template", func() {
+			It("returns an error", func() {
+				_, err := NewBrowser([]string{"some-webdriver"}, "http://{{address}}/%@")
+				Expect(err).To(MatchError(ContainSubstring("invalid WebDriver URL:")))
+			})
+		})
+
+		Context("without a command", func() {
+			It("returns an error", func() {
+				_, err := NewBrowser(nil, "http://{{address}}")
+				Expect(err).To(MatchError("a command is required"))
+			})
+		})
+	})
+
+	Describe(".Firefox", func() {
+		It("returns a browser that writes its driver log to a file", func() {
+			browser, err := Firefox(DriverLog("some-log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(browser).NotTo(BeNil())
+		})
+
+		Context("with a driver log and output directed elsewhere", func() {
+			It("returns an error", func() {
+				_, err := Firefox(DriverLog("some-log"), OutputFile("some-other-log"))
+				Expect(err).To(MatchError("geckodriver logs to its output, so DriverLog cannot be combined with Output or OutputFile"))
+				_, err = Firefox(DriverLog("some-log"), Output(&bytes.Buffer{}))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
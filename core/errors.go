@@ -0,0 +1,41 @@
+package core
+
+import "github.com/sclevine/agouti/core/internal/types"
+
+// Errors returned by Page and Selection methods wrap these values when the
+// WebDriver reports the corresponding failure. Use errors.Is to detect them.
+var (
+	ErrNoSuchElement     = types.ErrNoSuchElement
+	ErrStaleElement      = types.ErrStaleElement
+	ErrElementNotVisible = types.ErrElementNotVisible
+	ErrTimeout           = types.ErrTimeout
+	ErrUnexpectedAlert   = types.ErrUnexpectedAlert
+	ErrNoSuchAlert       = types.ErrNoSuchAlert
+	ErrSessionNotFound   = types.ErrSessionNotFound
+	ErrUnknownCommand    = types.ErrUnknownCommand
+)
+
+// ErrWebDriverExited is wrapped by errors returned from a Browser or Page when
+// the WebDriver process launched by the Browser exited without being stopped.
+var ErrWebDriverExited = types.ErrWebDriverExited
+
+// WebDriverError describes a failure reported by the WebDriver, including the
+// W3C error code or JSON Wire status, the HTTP status, the endpoint and the raw message.
+// Use errors.As to retrieve it from an error returned by a Page or Selection.
+type WebDriverError = types.WebDriverError
+
+// UnexpectedAlertError describes a request that failed because a popup is open.
+// Text is the message of the popup. It satisfies errors.Is(err, ErrUnexpectedAlert).
+type UnexpectedAlertError = types.UnexpectedAlertError
+
+// TransportError describes a request that never received a response from the WebDriver.
+type TransportError = types.TransportError
+
+// MultipleElementsFoundError is returned when a selection that must refer to a
+// single element matches more than one. Count is the number of matched elements.
+type MultipleElementsFoundError = types.MultipleElementsFoundError
+
+// NoElementFoundError is returned when a selection that must refer to a single
+// element matches none. Selector is the full selector chain that was attempted.
+// It satisfies errors.Is(err, ErrNoSuchElement).
+type NoElementFoundError = types.NoElementFoundError
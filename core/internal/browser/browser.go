@@ -1,38 +1,85 @@
 package browser
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/sclevine/agouti/core/internal/page"
-	"github.com/sclevine/agouti/core/internal/service"
 	"github.com/sclevine/agouti/core/internal/session"
 	"github.com/sclevine/agouti/core/internal/types"
 	"github.com/sclevine/agouti/core/internal/webdriver"
+	"time"
 )
 
 type Browser struct {
-	Service  browserService
-	sessions []destroyable
+	Service      browserService
+	Timeout      time.Duration
+	LoggingPrefs map[string]string
+	PageTimeouts Timeouts
+	sessions     []destroyable
+}
+
+// Timeouts are set on every page created by a Browser.
+// Zero values leave the WebDriver defaults in place.
+type Timeouts struct {
+	PageLoad time.Duration
+	Script   time.Duration
+	Implicit time.Duration
+}
+
+func (t Timeouts) apply(newPage *page.Page) error {
+	if t.PageLoad != 0 {
+		if err := newPage.SetPageLoadTimeout(t.PageLoad); err != nil {
+			return err
+		}
+	}
+	if t.Script != 0 {
+		if err := newPage.SetScriptTimeout(t.Script); err != nil {
+			return err
+		}
+	}
+	if t.Implicit != 0 {
+		if err := newPage.SetImplicitWait(t.Implicit); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type browserService interface {
-	Start() error
+	StartContext(ctx context.Context) error
 	Stop()
-	CreateSession(capabilities *service.Capabilities) (*session.Session, error)
+	WebDriverURL() string
+	CreateSessionContext(ctx context.Context, capabilities *types.Capabilities) (*session.Session, error)
 }
 
 type destroyable interface {
 	Destroy() error
 }
 
+func (b *Browser) timeout() time.Duration {
+	if b.Timeout == 0 {
+		return session.DefaultTimeout
+	}
+	return b.Timeout
+}
+
 func (b *Browser) Start() error {
-	if err := b.Service.Start(); err != nil {
-		return fmt.Errorf("failed to start service: %s", err)
+	return b.StartContext(context.Background())
+}
+
+func (b *Browser) StartContext(ctx context.Context) error {
+	if err := b.Service.StartContext(ctx); err != nil {
+		return fmt.Errorf("failed to start service: %w", err)
 	}
 
 	return nil
 }
 
+func (b *Browser) URL() string {
+	return b.Service.WebDriverURL()
+}
+
 func (b *Browser) Stop() (nonFatal error) {
 	for _, pageSession := range b.sessions {
 		if err := pageSession.Destroy(); err != nil {
@@ -45,19 +92,47 @@ func (b *Browser) Stop() (nonFatal error) {
 }
 
 func (b *Browser) Page(browserName ...string) (types.Page, error) {
-	capabilites := &service.Capabilities{}
+	return b.PageContext(context.Background(), browserName...)
+}
+
+func (b *Browser) PageContext(ctx context.Context, browserName ...string) (types.Page, error) {
+	capabilities := types.NewCapabilities()
 	if len(browserName) == 1 {
-		capabilites.BrowserName = browserName[0]
+		capabilities.Browser(browserName[0])
 	} else if len(browserName) > 1 {
 		return nil, errors.New("too many arguments")
 	}
+	if b.LoggingPrefs != nil {
+		capabilities.LoggingPrefs(b.LoggingPrefs)
+	}
+
+	return b.PageWithCapabilitiesContext(ctx, capabilities)
+}
 
-	pageSession, err := b.Service.CreateSession(capabilites)
+func (b *Browser) PageWithCapabilities(capabilities *types.Capabilities) (types.Page, error) {
+	return b.PageWithCapabilitiesContext(context.Background(), capabilities)
+}
+
+func (b *Browser) PageWithCapabilitiesContext(ctx context.Context, capabilities *types.Capabilities) (types.Page, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout())
+		defer cancel()
+	}
+
+	pageSession, err := b.Service.CreateSessionContext(ctx, capabilities)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate page: %s", err)
+		return nil, fmt.Errorf("failed to generate page: %w", err)
 	}
+	pageSession.Timeout = b.Timeout
 
-	b.sessions = append(b.sessions, pageSession)
 	pageDriver := &webdriver.Driver{Session: pageSession}
-	return &page.Page{Driver: pageDriver}, nil
+	newPage := &page.Page{Driver: pageDriver}
+	if err := b.PageTimeouts.apply(newPage); err != nil {
+		pageSession.Destroy()
+		return nil, fmt.Errorf("failed to generate page: %w", err)
+	}
+
+	b.sessions = append(b.sessions, pageSession)
+	return newPage, nil
 }
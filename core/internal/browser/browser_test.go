@@ -1,14 +1,18 @@
 package browser_test
 
 import (
+	"context"
 	"errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti/core/internal/browser"
 	"github.com/sclevine/agouti/core/internal/mocks"
 	"github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"time"
 )
 
 var _ = Describe("Browser", func() {
@@ -25,12 +29,12 @@ var _ = Describe("Browser", func() {
 	Describe("#Start", func() {
 		It("starts the service", func() {
 			browser.Start()
-			Expect(service.StartCall.Called).To(BeTrue())
+			Expect(service.StartContextCall.Ctx).NotTo(BeNil())
 		})
 
 		Context("when starting the service fails", func() {
 			It("returns an error", func() {
-				service.StartCall.Err = errors.New("some error")
+				service.StartContextCall.Err = errors.New("some error")
 				Expect(browser.Start()).To(MatchError("failed to start service: some error"))
 			})
 		})
@@ -43,6 +47,13 @@ var _ = Describe("Browser", func() {
 		})
 	})
 
+	Describe("#URL", func() {
+		It("returns the URL of the service", func() {
+			service.WebDriverURLCall.ReturnURL = "http://some-address"
+			Expect(browser.URL()).To(Equal("http://some-address"))
+		})
+	})
+
 	Describe("#Stop", func() {
 		var (
 			fakeServer      *httptest.Server
@@ -56,7 +67,7 @@ var _ = Describe("Browser", func() {
 				deletedSessions += 1
 				response.WriteHeader(destroyStatus)
 			}))
-			service.CreateSessionCall.ReturnSession = &session.Session{URL: fakeServer.URL}
+			service.CreateSessionContextCall.ReturnSession = &session.Session{URL: fakeServer.URL}
 			browser.Page()
 			browser.Page()
 		})
@@ -95,11 +106,15 @@ var _ = Describe("Browser", func() {
 	})
 
 	Describe("#Page", func() {
+		BeforeEach(func() {
+			service.CreateSessionContextCall.ReturnSession = &session.Session{}
+		})
+
 		Context("with zero arguments", func() {
 			It("creates a session with no browser name", func() {
 				_, err := browser.Page()
 				Expect(err).NotTo(HaveOccurred())
-				Expect(service.CreateSessionCall.Capabilities.BrowserName).To(Equal(""))
+				Expect(service.CreateSessionContextCall.Capabilities).To(Equal(types.NewCapabilities()))
 			})
 		})
 
@@ -107,7 +122,17 @@ var _ = Describe("Browser", func() {
 			It("creates a session with the provided browser name", func() {
 				_, err := browser.Page("some-name")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(service.CreateSessionCall.Capabilities.BrowserName).To(Equal("some-name"))
+				Expect(service.CreateSessionContextCall.Capabilities.Desired).To(Equal(map[string]interface{}{"browserName": "some-name"}))
+				Expect(service.CreateSessionContextCall.Capabilities.Required).To(BeEmpty())
+			})
+		})
+
+		Context("with logging preferences", func() {
+			It("creates a session with the logging preferences", func() {
+				browser.LoggingPrefs = map[string]string{"browser": "ALL"}
+				_, err := browser.Page()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(service.CreateSessionContextCall.Capabilities.Desired).To(HaveKeyWithValue("loggingPrefs", map[string]string{"browser": "ALL"}))
 			})
 		})
 
@@ -124,10 +149,98 @@ var _ = Describe("Browser", func() {
 				sessionInPage = true
 			}))
 			defer fakeServer.Close()
-			service.CreateSessionCall.ReturnSession = &session.Session{URL: fakeServer.URL}
+			service.CreateSessionContextCall.ReturnSession = &session.Session{URL: fakeServer.URL}
 			page, _ := browser.Page()
 			page.URL()
 			Expect(sessionInPage).To(BeTrue())
 		})
+
+		Context("with page timeouts", func() {
+			var (
+				requests       []string
+				responseStatus int
+				fakeServer     *httptest.Server
+			)
+
+			BeforeEach(func() {
+				requests = nil
+				responseStatus = 200
+				fakeServer = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					body, _ := ioutil.ReadAll(request.Body)
+					requests = append(requests, request.Method+" "+request.URL.Path+" "+string(body))
+					response.WriteHeader(responseStatus)
+					response.Write([]byte(`{"value": {}}`))
+				}))
+				service.CreateSessionContextCall.ReturnSession = &session.Session{URL: fakeServer.URL}
+				browser.PageTimeouts = Timeouts{PageLoad: time.Second, Implicit: 2 * time.Second}
+			})
+
+			AfterEach(func() {
+				fakeServer.Close()
+			})
+
+			It("sets the provided timeouts on the page", func() {
+				_, err := browser.Page()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requests).To(Equal([]string{
+					`POST /timeouts {"type":"page load","ms":1000}`,
+					`POST /timeouts {"type":"implicit","ms":2000}`,
+				}))
+			})
+
+			Context("when setting a timeout fails", func() {
+				It("destroys the session and returns an error", func() {
+					responseStatus = 500
+					_, err := browser.Page()
+					Expect(err).To(MatchError(ContainSubstring("failed to generate page: failed to set page load timeout:")))
+					Expect(requests).To(ContainElement("DELETE / "))
+				})
+			})
+		})
+
+		It("applies the browser timeout to the created session", func() {
+			browser.Timeout = 5 * time.Second
+			browser.Page()
+			Expect(service.CreateSessionContextCall.ReturnSession.Timeout).To(Equal(5 * time.Second))
+		})
+
+		It("bounds the creation of the session by the browser timeout", func() {
+			browser.Timeout = 5 * time.Second
+			browser.Page()
+			deadline, ok := service.CreateSessionContextCall.Ctx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(5*time.Second), time.Second))
+		})
+
+		Context("when the provided context has a deadline", func() {
+			It("creates the session with the provided deadline", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				defer cancel()
+				browser.PageContext(ctx)
+				deadline, _ := service.CreateSessionContextCall.Ctx.Deadline()
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+			})
+		})
+	})
+
+	Describe("#PageWithCapabilities", func() {
+		It("creates a session with the provided capabilities", func() {
+			service.CreateSessionContextCall.ReturnSession = &session.Session{}
+			capabilities := types.NewCapabilities().Browser("firefox").With("some:vendor", true)
+			_, err := browser.PageWithCapabilities(capabilities)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.CreateSessionContextCall.Capabilities).To(Equal(capabilities))
+		})
+	})
+
+	Describe("#PageContext", func() {
+		It("creates the session using the provided context", func() {
+			service.CreateSessionContextCall.ReturnSession = &session.Session{}
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := browser.PageContext(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.CreateSessionContextCall.Ctx.Err()).To(Equal(context.Canceled))
+		})
 	})
 })
@@ -1,15 +1,23 @@
 package mocks
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/sclevine/agouti/core/internal/types"
+	"time"
 )
 
 type Driver struct {
+	GetCapabilitiesCall struct {
+		ReturnCapabilities map[string]interface{}
+		Err                error
+	}
+
 	GetElementsCall struct {
 		Selector       types.Selector
 		ReturnElements []types.Element
 		Err            error
+		Count          int
 	}
 
 	GetWindowCall struct {
@@ -17,11 +25,76 @@ type Driver struct {
 		Err          error
 	}
 
+	GetWindowsCall struct {
+		ReturnWindows []types.Window
+		Err           error
+	}
+
+	SetWindowCall struct {
+		Window types.Window
+		Err    error
+	}
+
+	NewWindowCall struct {
+		Called       bool
+		ReturnWindow types.Window
+		Err          error
+	}
+
+	DeleteWindowCall struct {
+		Called bool
+		Err    error
+	}
+
+	SetFrameCall struct {
+		Frame  types.Element
+		Frames []types.Element
+		Err    error
+	}
+
+	SetParentFrameCall struct {
+		Called bool
+		Err    error
+	}
+
+	GetAlertTextCall struct {
+		ReturnText string
+		Err        error
+	}
+
+	SetAlertTextCall struct {
+		Text string
+		Err  error
+	}
+
+	AcceptAlertCall struct {
+		Called bool
+		Err    error
+	}
+
+	DismissAlertCall struct {
+		Called bool
+		Err    error
+	}
+
 	GetScreenshotCall struct {
 		ReturnImage []byte
 		Err         error
 	}
 
+	GetCookiesCall struct {
+		ReturnCookies []*types.Cookie
+		Err           error
+	}
+
+	GetLocalStorageCall struct {
+		ReturnStorage types.Storage
+	}
+
+	GetSessionStorageCall struct {
+		ReturnStorage types.Storage
+	}
+
 	SetCookieCall struct {
 		Cookie *types.Cookie
 		Err    error
@@ -57,6 +130,17 @@ type Driver struct {
 		Err          error
 	}
 
+	GetLogsCall struct {
+		LogType    string
+		ReturnLogs []types.Log
+		Err        error
+	}
+
+	GetLogTypesCall struct {
+		ReturnTypes []string
+		Err         error
+	}
+
 	DoubleClickCall struct {
 		Called bool
 		Err    error
@@ -75,6 +159,25 @@ type Driver struct {
 		Err       error
 	}
 
+	ExecuteAsyncCall struct {
+		Body      string
+		Arguments []interface{}
+		Result    string
+		Err       error
+	}
+
+	SetTimeoutCall struct {
+		Type    string
+		Timeout time.Duration
+		Err     error
+	}
+
+	GetTimeoutCall struct {
+		Type          string
+		ReturnTimeout time.Duration
+		Err           error
+	}
+
 	ForwardCall struct {
 		Called bool
 		Err    error
@@ -89,10 +192,19 @@ type Driver struct {
 		Called bool
 		Err    error
 	}
+
+	WithContextCall struct {
+		Ctx context.Context
+	}
+}
+
+func (d *Driver) GetCapabilities() (map[string]interface{}, error) {
+	return d.GetCapabilitiesCall.ReturnCapabilities, d.GetCapabilitiesCall.Err
 }
 
 func (d *Driver) GetElements(selector types.Selector) ([]types.Element, error) {
 	d.GetElementsCall.Selector = selector
+	d.GetElementsCall.Count++
 	return d.GetElementsCall.ReturnElements, d.GetElementsCall.Err
 }
 
@@ -100,10 +212,71 @@ func (d *Driver) GetWindow() (types.Window, error) {
 	return d.GetWindowCall.ReturnWindow, d.GetWindowCall.Err
 }
 
+func (d *Driver) GetWindows() ([]types.Window, error) {
+	return d.GetWindowsCall.ReturnWindows, d.GetWindowsCall.Err
+}
+
+func (d *Driver) SetWindow(window types.Window) error {
+	d.SetWindowCall.Window = window
+	return d.SetWindowCall.Err
+}
+
+func (d *Driver) NewWindow() (types.Window, error) {
+	d.NewWindowCall.Called = true
+	return d.NewWindowCall.ReturnWindow, d.NewWindowCall.Err
+}
+
+func (d *Driver) DeleteWindow() error {
+	d.DeleteWindowCall.Called = true
+	return d.DeleteWindowCall.Err
+}
+
+func (d *Driver) SetFrame(frame types.Element) error {
+	d.SetFrameCall.Frame = frame
+	d.SetFrameCall.Frames = append(d.SetFrameCall.Frames, frame)
+	return d.SetFrameCall.Err
+}
+
+func (d *Driver) SetParentFrame() error {
+	d.SetParentFrameCall.Called = true
+	return d.SetParentFrameCall.Err
+}
+
+func (d *Driver) GetAlertText() (string, error) {
+	return d.GetAlertTextCall.ReturnText, d.GetAlertTextCall.Err
+}
+
+func (d *Driver) SetAlertText(text string) error {
+	d.SetAlertTextCall.Text = text
+	return d.SetAlertTextCall.Err
+}
+
+func (d *Driver) AcceptAlert() error {
+	d.AcceptAlertCall.Called = true
+	return d.AcceptAlertCall.Err
+}
+
+func (d *Driver) DismissAlert() error {
+	d.DismissAlertCall.Called = true
+	return d.DismissAlertCall.Err
+}
+
 func (d *Driver) GetScreenshot() ([]byte, error) {
 	return d.GetScreenshotCall.ReturnImage, d.GetScreenshotCall.Err
 }
 
+func (d *Driver) GetCookies() ([]*types.Cookie, error) {
+	return d.GetCookiesCall.ReturnCookies, d.GetCookiesCall.Err
+}
+
+func (d *Driver) GetLocalStorage() types.Storage {
+	return d.GetLocalStorageCall.ReturnStorage
+}
+
+func (d *Driver) GetSessionStorage() types.Storage {
+	return d.GetSessionStorageCall.ReturnStorage
+}
+
 func (d *Driver) SetCookie(cookie *types.Cookie) error {
 	d.SetCookieCall.Cookie = cookie
 	return d.SetCookieCall.Err
@@ -136,6 +309,15 @@ func (d *Driver) GetSource() (string, error) {
 	return d.GetSourceCall.ReturnSource, d.GetSourceCall.Err
 }
 
+func (d *Driver) GetLogs(logType string) ([]types.Log, error) {
+	d.GetLogsCall.LogType = logType
+	return d.GetLogsCall.ReturnLogs, d.GetLogsCall.Err
+}
+
+func (d *Driver) GetLogTypes() ([]string, error) {
+	return d.GetLogTypesCall.ReturnTypes, d.GetLogTypesCall.Err
+}
+
 func (d *Driver) DoubleClick() error {
 	d.DoubleClickCall.Called = true
 	return d.DoubleClickCall.Err
@@ -154,6 +336,24 @@ func (d *Driver) Execute(body string, arguments []interface{}, result interface{
 	return d.ExecuteCall.Err
 }
 
+func (d *Driver) ExecuteAsync(body string, arguments []interface{}, result interface{}) error {
+	d.ExecuteAsyncCall.Body = body
+	d.ExecuteAsyncCall.Arguments = arguments
+	json.Unmarshal([]byte(d.ExecuteAsyncCall.Result), result)
+	return d.ExecuteAsyncCall.Err
+}
+
+func (d *Driver) SetTimeout(timeoutType string, timeout time.Duration) error {
+	d.SetTimeoutCall.Type = timeoutType
+	d.SetTimeoutCall.Timeout = timeout
+	return d.SetTimeoutCall.Err
+}
+
+func (d *Driver) GetTimeout(timeoutType string) (time.Duration, error) {
+	d.GetTimeoutCall.Type = timeoutType
+	return d.GetTimeoutCall.ReturnTimeout, d.GetTimeoutCall.Err
+}
+
 func (d *Driver) Forward() error {
 	d.ForwardCall.Called = true
 	return d.ForwardCall.Err
@@ -168,3 +368,8 @@ func (d *Driver) Refresh() error {
 	d.RefreshCall.Called = true
 	return d.RefreshCall.Err
 }
+
+func (d *Driver) WithContext(ctx context.Context) types.Driver {
+	d.WithContextCall.Ctx = ctx
+	return d
+}
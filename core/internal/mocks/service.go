@@ -1,37 +1,48 @@
 package mocks
 
 import (
-	"github.com/sclevine/agouti/core/internal/service"
+	"context"
 	"github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
 )
 
 type Service struct {
-	StartCall struct {
-		Called bool
-		Err    error
+	StartContextCall struct {
+		Ctx context.Context
+		Err error
 	}
 
 	StopCall struct {
 		Called bool
 	}
 
-	CreateSessionCall struct {
-		Capabilities  *service.Capabilities
+	WebDriverURLCall struct {
+		ReturnURL string
+	}
+
+	CreateSessionContextCall struct {
+		Ctx           context.Context
+		Capabilities  *types.Capabilities
 		ReturnSession *session.Session
 		Err           error
 	}
 }
 
-func (s *Service) Start() error {
-	s.StartCall.Called = true
-	return s.StartCall.Err
+func (s *Service) StartContext(ctx context.Context) error {
+	s.StartContextCall.Ctx = ctx
+	return s.StartContextCall.Err
 }
 
 func (s *Service) Stop() {
 	s.StopCall.Called = true
 }
 
-func (s *Service) CreateSession(capabilities *service.Capabilities) (*session.Session, error) {
-	s.CreateSessionCall.Capabilities = capabilities
-	return s.CreateSessionCall.ReturnSession, s.CreateSessionCall.Err
+func (s *Service) WebDriverURL() string {
+	return s.WebDriverURLCall.ReturnURL
+}
+
+func (s *Service) CreateSessionContext(ctx context.Context, capabilities *types.Capabilities) (*session.Session, error) {
+	s.CreateSessionContextCall.Ctx = ctx
+	s.CreateSessionContextCall.Capabilities = capabilities
+	return s.CreateSessionContextCall.ReturnSession, s.CreateSessionContextCall.Err
 }
@@ -1,9 +1,13 @@
 package mocks
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 type Session struct {
 	ExecuteCall struct {
+		Ctx      context.Context
 		Endpoint string
 		Method   string
 		BodyJSON []byte
@@ -24,6 +28,11 @@ func (s *Session) Execute(endpoint, method string, body, result interface{}) err
 	return s.ExecuteCall.Err
 }
 
+func (s *Session) ExecuteContext(ctx context.Context, endpoint, method string, body, result interface{}) error {
+	s.ExecuteCall.Ctx = ctx
+	return s.Execute(endpoint, method, body, result)
+}
+
 func (s *Session) Destroy() error {
 	return s.DestroyCall.Err
 }
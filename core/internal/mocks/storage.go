@@ -0,0 +1,55 @@
+package mocks
+
+type Storage struct {
+	GetCall struct {
+		Key         string
+		ReturnValue string
+		Err         error
+	}
+
+	SetCall struct {
+		Key   string
+		Value string
+		Err   error
+	}
+
+	DeleteCall struct {
+		Key string
+		Err error
+	}
+
+	KeysCall struct {
+		ReturnKeys []string
+		Err        error
+	}
+
+	ClearCall struct {
+		Called bool
+		Err    error
+	}
+}
+
+func (s *Storage) Get(key string) (string, error) {
+	s.GetCall.Key = key
+	return s.GetCall.ReturnValue, s.GetCall.Err
+}
+
+func (s *Storage) Set(key, value string) error {
+	s.SetCall.Key = key
+	s.SetCall.Value = value
+	return s.SetCall.Err
+}
+
+func (s *Storage) Delete(key string) error {
+	s.DeleteCall.Key = key
+	return s.DeleteCall.Err
+}
+
+func (s *Storage) Keys() ([]string, error) {
+	return s.KeysCall.ReturnKeys, s.KeysCall.Err
+}
+
+func (s *Storage) Clear() error {
+	s.ClearCall.Called = true
+	return s.ClearCall.Err
+}
@@ -1,11 +1,54 @@
 package mocks
 
 type Window struct {
+	ID string
+
+	GetSizeCall struct {
+		ReturnWidth  int
+		ReturnHeight int
+		Err          error
+	}
+
 	SizeCall struct {
 		Width  int
 		Height int
 		Err    error
 	}
+
+	GetPositionCall struct {
+		ReturnX int
+		ReturnY int
+		Err     error
+	}
+
+	SetPositionCall struct {
+		X   int
+		Y   int
+		Err error
+	}
+
+	MaximizeCall struct {
+		Called bool
+		Err    error
+	}
+
+	MinimizeCall struct {
+		Called bool
+		Err    error
+	}
+
+	FullscreenCall struct {
+		Called bool
+		Err    error
+	}
+}
+
+func (w *Window) GetID() string {
+	return w.ID
+}
+
+func (w *Window) GetSize() (width, height int, err error) {
+	return w.GetSizeCall.ReturnWidth, w.GetSizeCall.ReturnHeight, w.GetSizeCall.Err
 }
 
 func (w *Window) SetSize(width, height int) error {
@@ -13,3 +56,28 @@ func (w *Window) SetSize(width, height int) error {
 	w.SizeCall.Height = height
 	return w.SizeCall.Err
 }
+
+func (w *Window) GetPosition() (x, y int, err error) {
+	return w.GetPositionCall.ReturnX, w.GetPositionCall.ReturnY, w.GetPositionCall.Err
+}
+
+func (w *Window) SetPosition(x, y int) error {
+	w.SetPositionCall.X = x
+	w.SetPositionCall.Y = y
+	return w.SetPositionCall.Err
+}
+
+func (w *Window) Maximize() error {
+	w.MaximizeCall.Called = true
+	return w.MaximizeCall.Err
+}
+
+func (w *Window) Minimize() error {
+	w.MinimizeCall.Called = true
+	return w.MinimizeCall.Err
+}
+
+func (w *Window) Fullscreen() error {
+	w.FullscreenCall.Called = true
+	return w.FullscreenCall.Err
+}
@@ -0,0 +1,76 @@
+package page
+
+import (
+	"github.com/sclevine/agouti/core/internal/types"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type cookiePage interface {
+	GetCookies() ([]*types.Cookie, error)
+	AddCookie(cookie *types.Cookie) error
+}
+
+// CookieJar is an http.CookieJar backed by the cookies of a browser session.
+// A browser only exposes and accepts cookies for the domain it is currently on,
+// so the page must be on the domain of the URL when the jar is used.
+// Errors from the WebDriver are discarded, as the http.CookieJar interface cannot report them.
+type CookieJar struct {
+	Page cookiePage
+}
+
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	for _, cookie := range cookies {
+		browserCookie := types.CookieFromHTTP(cookie)
+		if browserCookie.Domain == "" {
+			browserCookie.Domain = u.Hostname()
+		}
+		if browserCookie.Path == "" {
+			browserCookie.Path = "/"
+		}
+		j.Page.AddCookie(browserCookie)
+	}
+}
+
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	cookies, err := j.Page.GetCookies()
+	if err != nil {
+		return nil
+	}
+
+	var matching []*http.Cookie
+	for _, cookie := range cookies {
+		if cookieMatches(cookie, u) {
+			matching = append(matching, &http.Cookie{Name: cookie.Name, Value: cookie.Value})
+		}
+	}
+	return matching
+}
+
+func cookieMatches(cookie *types.Cookie, u *url.URL) bool {
+	host := u.Hostname()
+	domain := strings.TrimPrefix(cookie.Domain, ".")
+	if domain != "" && host != domain && !strings.HasSuffix(host, "."+domain) {
+		return false
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	if cookie.Path != "" && !pathMatches(path, cookie.Path) {
+		return false
+	}
+
+	return !cookie.Secure || u.Scheme == "https"
+}
+
+// pathMatches reports whether a request path is within the path of a cookie,
+// as described by RFC 6265 section 5.1.4.
+func pathMatches(path, cookiePath string) bool {
+	if !strings.HasPrefix(path, cookiePath) {
+		return false
+	}
+	return len(path) == len(cookiePath) || strings.HasSuffix(cookiePath, "/") || path[len(cookiePath)] == '/'
+}
@@ -0,0 +1,78 @@
+package page_test
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/page"
+	"github.com/sclevine/agouti/core/internal/types"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var _ = Describe("CookieJar", func() {
+	var (
+		jar    *CookieJar
+		driver *mocks.Driver
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		jar = &CookieJar{Page: &Page{Driver: driver}}
+	})
+
+	Describe("#SetCookies", func() {
+		It("adds the cookies to the browser on the domain of the URL", func() {
+			pageURL, _ := url.Parse("http://example.com/some/path")
+			expires := time.Unix(1412358590, 0)
+			jar.SetCookies(pageURL, []*http.Cookie{{Name: "some-name", Value: "some-value", Expires: expires, HttpOnly: true, SameSite: http.SameSiteLaxMode}})
+			Expect(driver.SetCookieCall.Cookie).To(Equal(&types.Cookie{
+				Name:     "some-name",
+				Value:    "some-value",
+				Path:     "/",
+				Domain:   "example.com",
+				HTTPOnly: true,
+				Expiry:   1412358590,
+				SameSite: "Lax",
+			}))
+		})
+	})
+
+	Describe("#Cookies", func() {
+		BeforeEach(func() {
+			driver.GetCookiesCall.ReturnCookies = []*types.Cookie{
+				{Name: "some-name", Value: "some-value", Domain: ".example.com", Path: "/"},
+				{Name: "some-path", Value: "some-value", Domain: "example.com", Path: "/other"},
+				{Name: "some-secure", Value: "some-value", Domain: "example.com", Secure: true},
+				{Name: "some-other-domain", Value: "some-value", Domain: "example.org"},
+			}
+		})
+
+		It("returns the browser cookies that apply to the URL", func() {
+			pageURL, _ := url.Parse("http://www.example.com/some/path")
+			Expect(jar.Cookies(pageURL)).To(Equal([]*http.Cookie{{Name: "some-name", Value: "some-value"}}))
+		})
+
+		It("returns secure cookies for HTTPS URLs", func() {
+			pageURL, _ := url.Parse("https://example.com/other/path")
+			Expect(jar.Cookies(pageURL)).To(HaveLen(3))
+		})
+
+		It("returns cookies only for paths on a path boundary", func() {
+			pageURL, _ := url.Parse("http://example.com/other")
+			Expect(jar.Cookies(pageURL)).To(HaveLen(2))
+			pageURL, _ = url.Parse("http://example.com/otherpath")
+			Expect(jar.Cookies(pageURL)).To(Equal([]*http.Cookie{{Name: "some-name", Value: "some-value"}}))
+		})
+
+		Context("when the browser cookies cannot be retrieved", func() {
+			It("returns no cookies", func() {
+				driver.GetCookiesCall.Err = errors.New("some error")
+				pageURL, _ := url.Parse("http://example.com")
+				Expect(jar.Cookies(pageURL)).To(BeEmpty())
+			})
+		})
+	})
+})
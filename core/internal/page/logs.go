@@ -0,0 +1,84 @@
+package page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+	"time"
+)
+
+const browserLog = "browser"
+
+// logScript hooks console.* and window.onerror the first time it runs on a
+// document, and returns (and clears) the entries captured since its last run.
+const logScript = `if (!window.__agoutiLogs) {
+	window.__agoutiLogs = [];
+	var record = function(level, message) {
+		window.__agoutiLogs.push({level: level, message: message, timestamp: Date.now()});
+	};
+	var levels = {log: "INFO", info: "INFO", debug: "DEBUG", warn: "WARNING", error: "SEVERE"};
+	Object.keys(levels).forEach(function(method) {
+		var original = console[method];
+		console[method] = function() {
+			record(levels[method], Array.prototype.map.call(arguments, String).join(" "));
+			if (original) {
+				return original.apply(console, arguments);
+			}
+		};
+	});
+	window.addEventListener("error", function(event) {
+		var location = event.filename ? " (" + event.filename + ":" + event.lineno + ")" : "";
+		record("SEVERE", event.message + location);
+	});
+}
+return window.__agoutiLogs.splice(0, window.__agoutiLogs.length);`
+
+// ReadLogs returns the entries of the provided log type (such as "browser")
+// that were recorded since the last call to ReadLogs.
+// When the WebDriver does not provide logs, the "browser" log is read from
+// console.* and window.onerror hooks injected into the page instead. These hooks
+// only capture entries logged after the first call to ReadLogs on each document.
+func (p *Page) ReadLogs(logType string) ([]types.Log, error) {
+	logs, err := p.Driver.GetLogs(logType)
+	if errors.Is(err, types.ErrUnknownCommand) && logType == browserLog {
+		return p.readScriptLogs()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve logs: %w", err)
+	}
+	return logs, nil
+}
+
+func (p *Page) readScriptLogs() ([]types.Log, error) {
+	var entries []struct {
+		Level     string  `json:"level"`
+		Message   string  `json:"message"`
+		Timestamp float64 `json:"timestamp"`
+	}
+	if err := p.Driver.Execute(logScript, nil, &entries); err != nil {
+		return nil, fmt.Errorf("failed to retrieve logs: %w", err)
+	}
+
+	logs := make([]types.Log, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, types.Log{
+			Message: entry.Message,
+			Level:   entry.Level,
+			Time:    time.Unix(0, int64(entry.Timestamp)*int64(time.Millisecond)),
+		})
+	}
+	return logs, nil
+}
+
+// LogTypes returns the log types that may be passed to ReadLogs.
+// When the WebDriver does not provide logs, only the "browser" log is available.
+func (p *Page) LogTypes() ([]string, error) {
+	logTypes, err := p.Driver.GetLogTypes()
+	if errors.Is(err, types.ErrUnknownCommand) {
+		return []string{browserLog}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve log types: %w", err)
+	}
+	return logTypes, nil
+}
@@ -0,0 +1,89 @@
+package page_test
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/page"
+	"github.com/sclevine/agouti/core/internal/types"
+	"time"
+)
+
+var _ = Describe("Logs", func() {
+	var (
+		page   *Page
+		driver *mocks.Driver
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		page = &Page{Driver: driver}
+	})
+
+	Describe("#ReadLogs", func() {
+		It("returns the logs of the provided type", func() {
+			logs := []types.Log{{Message: "some message", Level: "INFO", Time: time.Unix(1, 0)}}
+			driver.GetLogsCall.ReturnLogs = logs
+			Expect(page.ReadLogs("browser")).To(Equal(logs))
+			Expect(driver.GetLogsCall.LogType).To(Equal("browser"))
+		})
+
+		Context("when the driver fails to retrieve the logs", func() {
+			It("returns an error", func() {
+				driver.GetLogsCall.Err = errors.New("some error")
+				_, err := page.ReadLogs("browser")
+				Expect(err).To(MatchError("failed to retrieve logs: some error"))
+			})
+		})
+
+		Context("when the driver does not provide logs", func() {
+			BeforeEach(func() {
+				driver.GetLogsCall.Err = &types.WebDriverError{Code: "unknown command"}
+				driver.ExecuteCall.Result = `[{"level": "SEVERE", "message": "some error", "timestamp": 1412358590123}]`
+			})
+
+			It("reads the browser log from hooks injected into the page", func() {
+				logs, err := page.ReadLogs("browser")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(driver.ExecuteCall.Body).To(ContainSubstring("window.__agoutiLogs"))
+				Expect(logs).To(Equal([]types.Log{{Message: "some error", Level: "SEVERE", Time: time.Unix(1412358590, 123000000)}}))
+			})
+
+			It("returns an error for other log types", func() {
+				_, err := page.ReadLogs("driver")
+				Expect(err).To(MatchError("failed to retrieve logs: request unsuccessful: unknown command: "))
+			})
+
+			Context("when the injected hooks cannot be read", func() {
+				It("returns an error", func() {
+					driver.ExecuteCall.Err = errors.New("some error")
+					_, err := page.ReadLogs("browser")
+					Expect(err).To(MatchError("failed to retrieve logs: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#LogTypes", func() {
+		It("returns the available log types", func() {
+			driver.GetLogTypesCall.ReturnTypes = []string{"browser", "driver"}
+			Expect(page.LogTypes()).To(Equal([]string{"browser", "driver"}))
+		})
+
+		Context("when the driver fails to retrieve the log types", func() {
+			It("returns an error", func() {
+				driver.GetLogTypesCall.Err = errors.New("some error")
+				_, err := page.LogTypes()
+				Expect(err).To(MatchError("failed to retrieve log types: some error"))
+			})
+		})
+
+		Context("when the driver does not provide logs", func() {
+			It("returns only the browser log type", func() {
+				driver.GetLogTypesCall.Err = &types.WebDriverError{Code: "unknown command"}
+				Expect(page.LogTypes()).To(Equal([]string{"browser"}))
+			})
+		})
+	})
+})
@@ -1,115 +1,503 @@
 package page
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/sclevine/agouti/core/internal/selection"
 	"github.com/sclevine/agouti/core/internal/types"
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Page struct {
-	Driver driver
-}
-
-type driver interface {
-	GetWindow() (types.Window, error)
-	GetScreenshot() ([]byte, error)
-	SetCookie(cookie *types.Cookie) error
-	DeleteCookie(name string) error
-	DeleteCookies() error
-	GetURL() (string, error)
-	SetURL(url string) error
-	GetTitle() (string, error)
-	GetSource() (string, error)
-	GetElements(selector types.Selector) ([]types.Element, error)
-	DoubleClick() error
-	MoveTo(element types.Element, point types.Point) error
-	Execute(body string, arguments []interface{}, result interface{}) error
-	Forward() error
-	Back() error
-	Refresh() error
+	Driver types.Driver
+	Wait   selection.Wait
+	ctx    context.Context
+}
+
+func (p *Page) WithContext(ctx context.Context) types.Page {
+	return &Page{Driver: p.Driver.WithContext(ctx), Wait: p.Wait.WithContext(ctx), ctx: ctx}
+}
+
+func (p *Page) context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
+// WithTimeout returns a page whose selections retry finding, acting on and
+// reading elements for up to timeout while they are missing, ambiguous, stale
+// or not interactable. Methods of the page itself are not retried.
+func (p *Page) WithTimeout(timeout time.Duration) types.Page {
+	page := *p
+	page.Wait.Timeout = timeout
+	return &page
+}
+
+// WithPollInterval returns a page whose selections retry at the provided
+// interval when it has a timeout.
+func (p *Page) WithPollInterval(interval time.Duration) types.Page {
+	page := *p
+	page.Wait.Interval = interval
+	return &page
+}
+
+func (p *Page) Capabilities() (map[string]interface{}, error) {
+	capabilities, err := p.Driver.GetCapabilities()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve capabilities: %w", err)
+	}
+	return capabilities, nil
 }
 
 func (p *Page) Navigate(url string) error {
 	if err := p.Driver.SetURL(url); err != nil {
-		return fmt.Errorf("failed to navigate: %s", err)
+		return fmt.Errorf("failed to navigate: %w", err)
+	}
+	return nil
+}
+
+func (p *Page) GetCookies() ([]*types.Cookie, error) {
+	cookies, err := p.Driver.GetCookies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve cookies: %w", err)
+	}
+	return cookies, nil
+}
+
+func (p *Page) AddCookie(cookie *types.Cookie) error {
+	if err := p.Driver.SetCookie(cookie); err != nil {
+		return fmt.Errorf("failed to set cookie: %w", err)
 	}
 	return nil
 }
 
 func (p *Page) SetCookie(name string, value interface{}, path, domain string, secure, httpOnly bool, expiry int64) error {
-	cookie := types.Cookie{Name: name, Value: value, Path: path, Domain: domain, Secure: secure, HTTPOnly: httpOnly, Expiry: expiry}
+	cookie := types.Cookie{Name: name, Value: fmt.Sprint(value), Path: path, Domain: domain, Secure: secure, HTTPOnly: httpOnly, Expiry: expiry}
 	if err := p.Driver.SetCookie(&cookie); err != nil {
-		return fmt.Errorf("failed to set cookie: %s", err)
+		return fmt.Errorf("failed to set cookie: %w", err)
 	}
 	return nil
 }
 
 func (p *Page) DeleteCookie(name string) error {
 	if err := p.Driver.DeleteCookie(name); err != nil {
-		return fmt.Errorf("failed to delete cookie %s: %s", name, err)
+		return fmt.Errorf("failed to delete cookie %s: %w", name, err)
 	}
 	return nil
 }
 
 func (p *Page) ClearCookies() error {
 	if err := p.Driver.DeleteCookies(); err != nil {
-		return fmt.Errorf("failed to clear cookies: %s", err)
+		return fmt.Errorf("failed to clear cookies: %w", err)
 	}
 	return nil
 }
 
+// LocalStorage returns the localStorage of the origin of the current page.
+func (p *Page) LocalStorage() types.Storage {
+	return &Storage{"localStorage", p.Driver.GetLocalStorage()}
+}
+
+// SessionStorage returns the sessionStorage of the origin of the current page.
+func (p *Page) SessionStorage() types.Storage {
+	return &Storage{"sessionStorage", p.Driver.GetSessionStorage()}
+}
+
 func (p *Page) URL() (string, error) {
 	url, err := p.Driver.GetURL()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve URL: %s", err)
+		return "", fmt.Errorf("failed to retrieve URL: %w", err)
 	}
 	return url, nil
 }
 
 func (p *Page) Size(width, height int) error {
-	window, err := p.Driver.GetWindow()
+	window, err := p.window()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve window: %s", err)
+		return err
 	}
 
 	if err := window.SetSize(width, height); err != nil {
-		return fmt.Errorf("failed to set window size: %s", err)
+		return fmt.Errorf("failed to set window size: %w", err)
+	}
+
+	return nil
+}
+
+// WindowSize returns the outer width and height of the current window.
+func (p *Page) WindowSize() (width, height int, err error) {
+	window, err := p.window()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	width, height, err = window.GetSize()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window size: %w", err)
+	}
+
+	return width, height, nil
+}
+
+// WindowPosition returns the position of the current window on the screen.
+func (p *Page) WindowPosition() (x, y int, err error) {
+	window, err := p.window()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	x, y, err = window.GetPosition()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window position: %w", err)
+	}
+
+	return x, y, nil
+}
+
+// SetWindowPosition moves the current window to the provided position on the screen.
+func (p *Page) SetWindowPosition(x, y int) error {
+	window, err := p.window()
+	if err != nil {
+		return err
+	}
+
+	if err := window.SetPosition(x, y); err != nil {
+		return fmt.Errorf("failed to set window position: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Page) Maximize() error {
+	window, err := p.window()
+	if err != nil {
+		return err
+	}
+
+	if err := window.Maximize(); err != nil {
+		return fmt.Errorf("failed to maximize window: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Page) Minimize() error {
+	window, err := p.window()
+	if err != nil {
+		return err
+	}
+
+	if err := window.Minimize(); err != nil {
+		return fmt.Errorf("failed to minimize window: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Page) Fullscreen() error {
+	window, err := p.window()
+	if err != nil {
+		return err
+	}
+
+	if err := window.Fullscreen(); err != nil {
+		return fmt.Errorf("failed to make window fullscreen: %w", err)
+	}
+
+	return nil
+}
+
+const viewportScript = `return {width: window.innerWidth, height: window.innerHeight};`
+
+// SetViewport resizes the current window so that the area in which the page
+// is rendered is exactly width by height pixels. Unlike Size, which sets the
+// outer size of the window, it compensates for the toolbars and borders of the browser.
+func (p *Page) SetViewport(width, height int) error {
+	window, err := p.window()
+	if err != nil {
+		return err
+	}
+
+	outerWidth, outerHeight, err := window.GetSize()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve window size: %w", err)
+	}
+
+	var viewport struct{ Width, Height int }
+	if err := p.Driver.Execute(viewportScript, nil, &viewport); err != nil {
+		return fmt.Errorf("failed to retrieve viewport size: %w", err)
+	}
+
+	newWidth := width + outerWidth - viewport.Width
+	newHeight := height + outerHeight - viewport.Height
+	if err := window.SetSize(newWidth, newHeight); err != nil {
+		return fmt.Errorf("failed to set window size: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Page) window() (types.Window, error) {
+	window, err := p.Driver.GetWindow()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve window: %w", err)
+	}
+	return window, nil
+}
+
+// scriptWindowTimeout bounds how long NewWindow waits for a window opened by a
+// script to appear, when the WebDriver does not provide the /window/new endpoint.
+const scriptWindowTimeout = 5 * time.Second
+
+func (p *Page) Windows() ([]string, error) {
+	windows, err := p.Driver.GetWindows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve windows: %w", err)
+	}
+
+	handles := []string{}
+	for _, window := range windows {
+		handles = append(handles, window.GetID())
+	}
+	return handles, nil
+}
+
+func (p *Page) SwitchToWindow(handle string) error {
+	windows, err := p.Driver.GetWindows()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve windows: %w", err)
+	}
+
+	for _, window := range windows {
+		if window.GetID() == handle {
+			if err := p.Driver.SetWindow(window); err != nil {
+				return fmt.Errorf("failed to switch to window: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no window found with handle %s", handle)
+}
+
+func (p *Page) SwitchToWindowByTitle(title string) error {
+	return p.switchToWindowWhere("title", title, p.Driver.GetTitle)
+}
+
+func (p *Page) SwitchToWindowByURL(url string) error {
+	return p.switchToWindowWhere("URL", url, p.Driver.GetURL)
+}
+
+// switchToWindowWhere switches to each window in turn until property returns
+// the expected value, returning to the original window if none match.
+func (p *Page) switchToWindowWhere(name, expected string, property func() (string, error)) error {
+	original, err := p.Driver.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve window: %w", err)
+	}
+
+	windows, err := p.Driver.GetWindows()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve windows: %w", err)
+	}
+
+	for _, window := range windows {
+		if err := p.Driver.SetWindow(window); err != nil {
+			return fmt.Errorf("failed to switch to window: %w", err)
+		}
+
+		actual, err := property()
+		if err != nil {
+			return fmt.Errorf("failed to retrieve window %s: %w", name, err)
+		}
+
+		if actual == expected {
+			return nil
+		}
+	}
+
+	if err := p.Driver.SetWindow(original); err != nil {
+		return fmt.Errorf("failed to switch back to original window: %w", err)
+	}
+
+	return fmt.Errorf("no window found with %s %s", name, expected)
+}
+
+// NewWindow opens a new blank window and switches to it.
+func (p *Page) NewWindow() (string, error) {
+	handle, err := p.newWindow()
+	if err != nil {
+		return "", fmt.Errorf("failed to open new window: %w", err)
 	}
 
+	if err := p.SwitchToWindow(handle); err != nil {
+		return "", err
+	}
+	return handle, nil
+}
+
+// newWindow opens a new window with the W3C /window/new endpoint, or with a
+// script when the WebDriver does not provide it.
+func (p *Page) newWindow() (string, error) {
+	window, err := p.Driver.NewWindow()
+	if err == nil {
+		return window.GetID(), nil
+	}
+	if !errors.Is(err, types.ErrUnknownCommand) {
+		return "", err
+	}
+
+	return p.WaitForNewWindow(scriptWindowTimeout, func() error {
+		return p.Driver.Execute("window.open('about:blank');", nil, &struct{}{})
+	})
+}
+
+// CloseWindow closes the current window. Switch to another window before
+// making further requests.
+func (p *Page) CloseWindow() error {
+	if err := p.Driver.DeleteWindow(); err != nil {
+		return fmt.Errorf("failed to close window: %w", err)
+	}
 	return nil
 }
 
-func (p *Page) Screenshot(filename string) error {
+// WaitForNewWindow performs action and returns the handle of the window it
+// opens, waiting for up to timeout, or until the context of the page is done,
+// for the window to appear.
+func (p *Page) WaitForNewWindow(timeout time.Duration, action func() error) (string, error) {
+	existing, err := p.Windows()
+	if err != nil {
+		return "", err
+	}
+
+	known := map[string]bool{}
+	for _, handle := range existing {
+		known[handle] = true
+	}
+
+	if err := action(); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(p.context(), timeout)
+	defer cancel()
+
+	interval := p.Wait.Interval
+	if interval <= 0 {
+		interval = selection.DefaultPollInterval
+	}
+
+	for {
+		current, err := p.Windows()
+		if err != nil {
+			return "", err
+		}
+
+		for _, handle := range current {
+			if !known[handle] {
+				return handle, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return "", errors.New("no new window was opened")
+			}
+			return "", fmt.Errorf("no new window was opened: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Screenshot writes a PNG screenshot of the page to filename. Any provided
+// selections are outlined for the duration of the capture.
+func (p *Page) Screenshot(filename string, highlight ...types.Selection) error {
 	if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
-		return fmt.Errorf("failed to create directory for screenshot: %s", err)
+		return fmt.Errorf("failed to create directory for screenshot: %w", err)
 	}
 
 	file, err := os.Create(filename)
 	if err != nil {
-		return fmt.Errorf("failed to create file for screenshot: %s", err)
+		return fmt.Errorf("failed to create file for screenshot: %w", err)
 	}
 	defer file.Close()
 
-	screenshot, err := p.Driver.GetScreenshot()
+	screenshot, err := p.screenshot(highlight)
 	if err != nil {
 		os.Remove(filename)
-		return fmt.Errorf("failed to retrieve screenshot: %s", err)
+		return err
 	}
 
 	if _, err := file.Write(screenshot); err != nil {
-		return fmt.Errorf("failed to write file for screenshot: %s", err)
+		return fmt.Errorf("failed to write file for screenshot: %w", err)
 	}
 
 	return nil
 }
 
+// ScreenshotImage returns a screenshot of the page. Any provided selections
+// are outlined for the duration of the capture.
+func (p *Page) ScreenshotImage(highlight ...types.Selection) (image.Image, error) {
+	screenshot, err := p.screenshot(highlight)
+	if err != nil {
+		return nil, err
+	}
+
+	pageImage, err := png.Decode(bytes.NewReader(screenshot))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+
+	return pageImage, nil
+}
+
+type highlighter interface {
+	Highlight() error
+	RemoveHighlight() error
+}
+
+func (p *Page) screenshot(highlight []types.Selection) ([]byte, error) {
+	var highlighted []highlighter
+	defer func() {
+		for _, selection := range highlighted {
+			selection.RemoveHighlight()
+		}
+	}()
+
+	for _, selection := range highlight {
+		target, ok := selection.(highlighter)
+		if !ok {
+			return nil, fmt.Errorf("failed to highlight '%s': selection cannot be highlighted", selection)
+		}
+
+		if err := target.Highlight(); err != nil {
+			return nil, err
+		}
+		highlighted = append(highlighted, target)
+	}
+
+	screenshot, err := p.Driver.GetScreenshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve screenshot: %w", err)
+	}
+
+	return screenshot, nil
+}
+
 func (p *Page) Title() (string, error) {
 	title, err := p.Driver.GetTitle()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve page title: %s", err)
+		return "", fmt.Errorf("failed to retrieve page title: %w", err)
 	}
 	return title, nil
 }
@@ -117,11 +505,45 @@ func (p *Page) Title() (string, error) {
 func (p *Page) HTML() (string, error) {
 	html, err := p.Driver.GetSource()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve page HTML: %s", err)
+		return "", fmt.Errorf("failed to retrieve page HTML: %w", err)
 	}
 	return html, nil
 }
 
+// PopupText returns the message of the open alert, confirm or prompt popup.
+// The error satisfies errors.Is(err, types.ErrNoSuchAlert) when no popup is open.
+func (p *Page) PopupText() (string, error) {
+	text, err := p.Driver.GetAlertText()
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve popup text: %w", err)
+	}
+	return text, nil
+}
+
+// EnterPopupText enters text into the open prompt popup.
+func (p *Page) EnterPopupText(text string) error {
+	if err := p.Driver.SetAlertText(text); err != nil {
+		return fmt.Errorf("failed to enter popup text: %w", err)
+	}
+	return nil
+}
+
+// ConfirmPopup accepts the open alert, confirm or prompt popup.
+func (p *Page) ConfirmPopup() error {
+	if err := p.Driver.AcceptAlert(); err != nil {
+		return fmt.Errorf("failed to confirm popup: %w", err)
+	}
+	return nil
+}
+
+// CancelPopup dismisses the open alert, confirm or prompt popup.
+func (p *Page) CancelPopup() error {
+	if err := p.Driver.DismissAlert(); err != nil {
+		return fmt.Errorf("failed to cancel popup: %w", err)
+	}
+	return nil
+}
+
 func (p *Page) RunScript(body string, arguments map[string]interface{}, result interface{}) error {
 	var (
 		keys   []string
@@ -137,7 +559,72 @@ func (p *Page) RunScript(body string, arguments map[string]interface{}, result i
 	cleanBody := fmt.Sprintf("return (function(%s) { %s; }).apply(this, arguments);", argumentList, body)
 
 	if err := p.Driver.Execute(cleanBody, values, result); err != nil {
-		return fmt.Errorf("failed to run script: %s", err)
+		return fmt.Errorf("failed to run script: %w", err)
+	}
+
+	return nil
+}
+
+const asyncScript = `(function(%s) { %s; }).apply(this, arguments);`
+
+const promiseScript = `var callback = arguments[arguments.length - 1];
+Promise.resolve((function(%s) { %s; }).apply(this, arguments)).then(callback, function(reason) {
+	callback({"agoutiRejection": String(reason)});
+});`
+
+// RunAsyncScript runs the script body with the provided arguments, and waits
+// for it to call callback, which is provided as an additional argument, with the result.
+// The script fails if it does not finish within the script timeout set by SetScriptTimeout.
+func (p *Page) RunAsyncScript(body string, arguments map[string]interface{}, result interface{}) error {
+	keys, values := scriptArguments(arguments)
+	cleanBody := fmt.Sprintf(asyncScript, strings.Join(append(keys, "callback"), ", "), body)
+
+	var rawResult json.RawMessage
+	if err := p.Driver.ExecuteAsync(cleanBody, values, &rawResult); err != nil {
+		return fmt.Errorf("failed to run script: %w", err)
+	}
+
+	return parseScriptResult(rawResult, result)
+}
+
+// AwaitScript runs the script body with the provided arguments, and waits for
+// the Promise it returns to settle. The resolved value is the result, and a
+// rejected Promise is returned as an error. Any other returned value is the result.
+// The script fails if it does not finish within the script timeout set by SetScriptTimeout.
+func (p *Page) AwaitScript(body string, arguments map[string]interface{}, result interface{}) error {
+	keys, values := scriptArguments(arguments)
+	cleanBody := fmt.Sprintf(promiseScript, strings.Join(keys, ", "), body)
+
+	var rawResult json.RawMessage
+	if err := p.Driver.ExecuteAsync(cleanBody, values, &rawResult); err != nil {
+		return fmt.Errorf("failed to run script: %w", err)
+	}
+
+	var rejection struct {
+		Reason *string `json:"agoutiRejection"`
+	}
+	if json.Unmarshal(rawResult, &rejection) == nil && rejection.Reason != nil {
+		return fmt.Errorf("failed to run script: promise rejected: %s", *rejection.Reason)
+	}
+
+	return parseScriptResult(rawResult, result)
+}
+
+func scriptArguments(arguments map[string]interface{}) (keys []string, values []interface{}) {
+	for key, value := range arguments {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values
+}
+
+func parseScriptResult(rawResult json.RawMessage, result interface{}) error {
+	if len(rawResult) == 0 || result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(rawResult, result); err != nil {
+		return fmt.Errorf("failed to parse script result: %w", err)
 	}
 
 	return nil
@@ -145,36 +632,65 @@ func (p *Page) RunScript(body string, arguments map[string]interface{}, result i
 
 func (p *Page) Forward() error {
 	if err := p.Driver.Forward(); err != nil {
-		return fmt.Errorf("failed to navigate forward in history: %s", err)
+		return fmt.Errorf("failed to navigate forward in history: %w", err)
 	}
 	return nil
 }
 
 func (p *Page) Back() error {
 	if err := p.Driver.Back(); err != nil {
-		return fmt.Errorf("failed to navigate backwards in history: %s", err)
+		return fmt.Errorf("failed to navigate backwards in history: %w", err)
 	}
 	return nil
 }
 
 func (p *Page) Refresh() error {
 	if err := p.Driver.Refresh(); err != nil {
-		return fmt.Errorf("failed to refresh page: %s", err)
+		return fmt.Errorf("failed to refresh page: %w", err)
 	}
 	return nil
 }
 
 func (p *Page) Find(selector string) types.Selection {
-	selection := &selection.Selection{Driver: p.Driver}
+	selection := &selection.Selection{Driver: p.Driver, Wait: p.Wait}
 	return selection.Find(selector)
 }
 
 func (p *Page) FindXPath(selector string) types.Selection {
-	selection := &selection.Selection{Driver: p.Driver}
+	selection := &selection.Selection{Driver: p.Driver, Wait: p.Wait}
 	return selection.FindXPath(selector)
 }
 
 func (p *Page) FindByLabel(text string) types.Selection {
-	selection := &selection.Selection{Driver: p.Driver}
+	selection := &selection.Selection{Driver: p.Driver, Wait: p.Wait}
 	return selection.FindByLabel(text)
 }
+
+// Frame returns a selection within the frame element matching the provided CSS
+// selector. Selections within a frame locate it from the top-level browsing
+// context and return to the top-level browsing context once they are used.
+func (p *Page) Frame(selector string) types.Selection {
+	frame := &selection.Selection{Driver: p.Driver, Wait: p.Wait}
+	return selection.NewFrame(frame.Find(selector).(*selection.Selection))
+}
+
+// SwitchToFrame switches to the frame element referred to by the provided selection.
+// Later selections on the page are made within that frame, so that successive
+// calls switch to frames nested within the previously selected frame.
+func (p *Page) SwitchToFrame(frame types.Selection) error {
+	return frame.SwitchToFrame()
+}
+
+func (p *Page) SwitchToParentFrame() error {
+	if err := p.Driver.SetParentFrame(); err != nil {
+		return fmt.Errorf("failed to switch to parent frame: %w", err)
+	}
+	return nil
+}
+
+func (p *Page) SwitchToRootFrame() error {
+	if err := p.Driver.SetFrame(nil); err != nil {
+		return fmt.Errorf("failed to switch to root frame: %w", err)
+	}
+	return nil
+}
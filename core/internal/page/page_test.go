@@ -1,14 +1,22 @@
 package page_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/sclevine/agouti/core/internal/mocks"
 	. "github.com/sclevine/agouti/core/internal/page"
+	"github.com/sclevine/agouti/core/internal/selection"
+	"github.com/sclevine/agouti/core/internal/types"
+	"image"
+	"image/png"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 var _ = Describe("Page", func() {
@@ -23,7 +31,49 @@ var _ = Describe("Page", func() {
 		driver = &mocks.Driver{}
 		window = &mocks.Window{}
 		element = &mocks.Element{}
-		page = &Page{driver}
+		page = &Page{Driver: driver}
+	})
+
+	Describe("#WithContext", func() {
+		It("returns a page with a driver scoped to the provided context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			Expect(page.WithContext(ctx).(*Page).Driver).To(Equal(driver))
+			Expect(driver.WithContextCall.Ctx).To(Equal(ctx))
+		})
+	})
+
+	Describe("#WithTimeout", func() {
+		It("returns a page whose selections retry for up to the provided timeout", func() {
+			timeoutPage := page.WithTimeout(time.Second).WithPollInterval(time.Millisecond)
+			Expect(timeoutPage.Find("#selector").(*selection.Selection).Wait).To(Equal(selection.Wait{Timeout: time.Second, Interval: time.Millisecond}))
+			Expect(timeoutPage.Frame("#frame").Find("#selector").(*selection.Selection).Wait.Timeout).To(Equal(time.Second))
+		})
+
+		It("keeps the timeout when scoped to a context", func() {
+			timeoutPage := page.WithTimeout(time.Second).WithContext(context.Background())
+			Expect(timeoutPage.FindXPath("//selector").(*selection.Selection).Wait.Timeout).To(Equal(time.Second))
+		})
+
+		It("does not change the original page", func() {
+			page.WithTimeout(time.Second)
+			Expect(page.Find("#selector").(*selection.Selection).Wait.Timeout).To(BeZero())
+		})
+	})
+
+	Describe("#Capabilities", func() {
+		It("returns the capabilities negotiated with the driver", func() {
+			driver.GetCapabilitiesCall.ReturnCapabilities = map[string]interface{}{"browserName": "chrome"}
+			Expect(page.Capabilities()).To(Equal(map[string]interface{}{"browserName": "chrome"}))
+		})
+
+		Context("when the driver fails to retrieve the capabilities", func() {
+			It("returns an error", func() {
+				driver.GetCapabilitiesCall.Err = errors.New("some error")
+				_, err := page.Capabilities()
+				Expect(err).To(MatchError("failed to retrieve capabilities: some error"))
+			})
+		})
 	})
 
 	Describe("#Navigate", func() {
@@ -49,11 +99,42 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#GetCookies", func() {
+		It("returns the cookies of the session", func() {
+			cookies := []*types.Cookie{{Name: "some-name", Value: "some-value"}}
+			driver.GetCookiesCall.ReturnCookies = cookies
+			Expect(page.GetCookies()).To(Equal(cookies))
+		})
+
+		Context("when the driver fails to retrieve the cookies", func() {
+			It("returns an error", func() {
+				driver.GetCookiesCall.Err = errors.New("some error")
+				_, err := page.GetCookies()
+				Expect(err).To(MatchError("failed to retrieve cookies: some error"))
+			})
+		})
+	})
+
+	Describe("#AddCookie", func() {
+		It("instructs the driver to add the cookie to the session", func() {
+			cookie := types.CookieFromHTTP(&http.Cookie{Name: "some-name", Value: "some-value"})
+			Expect(page.AddCookie(cookie)).To(Succeed())
+			Expect(driver.SetCookieCall.Cookie).To(Equal(cookie))
+		})
+
+		Context("when the driver fails to set the cookie", func() {
+			It("returns an error", func() {
+				driver.SetCookieCall.Err = errors.New("some error")
+				Expect(page.AddCookie(&types.Cookie{})).To(MatchError("failed to set cookie: some error"))
+			})
+		})
+	})
+
 	Describe("#SetCookie", func() {
 		It("instructs the driver to add the cookie to the session", func() {
 			page.SetCookie("some-name", 42, "/my-path", "example.com", false, false, 1412358590)
 			Expect(driver.SetCookieCall.Cookie.Name).To(Equal("some-name"))
-			Expect(driver.SetCookieCall.Cookie.Value).To(Equal(42))
+			Expect(driver.SetCookieCall.Cookie.Value).To(Equal("42"))
 		})
 
 		Context("when setting the cookie succeeds", func() {
@@ -116,6 +197,167 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#WindowSize", func() {
+		It("returns the size of the current window", func() {
+			window.GetSizeCall.ReturnWidth = 640
+			window.GetSizeCall.ReturnHeight = 480
+			driver.GetWindowCall.ReturnWindow = window
+			width, height, err := page.WindowSize()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(width).To(Equal(640))
+			Expect(height).To(Equal(480))
+		})
+
+		Context("when the window fails to retrieve its size", func() {
+			It("returns an error", func() {
+				window.GetSizeCall.Err = errors.New("some error")
+				driver.GetWindowCall.ReturnWindow = window
+				_, _, err := page.WindowSize()
+				Expect(err).To(MatchError("failed to retrieve window size: some error"))
+			})
+		})
+	})
+
+	Describe("#WindowPosition", func() {
+		It("returns the position of the current window", func() {
+			window.GetPositionCall.ReturnX = 10
+			window.GetPositionCall.ReturnY = 20
+			driver.GetWindowCall.ReturnWindow = window
+			x, y, err := page.WindowPosition()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(x).To(Equal(10))
+			Expect(y).To(Equal(20))
+		})
+
+		Context("when the driver fails to retrieve a window", func() {
+			It("returns an error", func() {
+				driver.GetWindowCall.Err = errors.New("some error")
+				_, _, err := page.WindowPosition()
+				Expect(err).To(MatchError("failed to retrieve window: some error"))
+			})
+		})
+	})
+
+	Describe("#SetWindowPosition", func() {
+		BeforeEach(func() {
+			driver.GetWindowCall.ReturnWindow = window
+		})
+
+		It("moves the current window to the provided position", func() {
+			Expect(page.SetWindowPosition(10, 20)).To(Succeed())
+			Expect(window.SetPositionCall.X).To(Equal(10))
+			Expect(window.SetPositionCall.Y).To(Equal(20))
+		})
+
+		Context("when the window fails to move", func() {
+			It("returns an error", func() {
+				window.SetPositionCall.Err = errors.New("some error")
+				Expect(page.SetWindowPosition(10, 20)).To(MatchError("failed to set window position: some error"))
+			})
+		})
+	})
+
+	Describe("#Maximize", func() {
+		BeforeEach(func() {
+			driver.GetWindowCall.ReturnWindow = window
+		})
+
+		It("maximizes the current window", func() {
+			Expect(page.Maximize()).To(Succeed())
+			Expect(window.MaximizeCall.Called).To(BeTrue())
+		})
+
+		Context("when the window fails to maximize", func() {
+			It("returns an error", func() {
+				window.MaximizeCall.Err = errors.New("some error")
+				Expect(page.Maximize()).To(MatchError("failed to maximize window: some error"))
+			})
+		})
+	})
+
+	Describe("#Minimize", func() {
+		BeforeEach(func() {
+			driver.GetWindowCall.ReturnWindow = window
+		})
+
+		It("minimizes the current window", func() {
+			Expect(page.Minimize()).To(Succeed())
+			Expect(window.MinimizeCall.Called).To(BeTrue())
+		})
+
+		Context("when the window fails to minimize", func() {
+			It("returns an error", func() {
+				window.MinimizeCall.Err = errors.New("some error")
+				Expect(page.Minimize()).To(MatchError("failed to minimize window: some error"))
+			})
+		})
+	})
+
+	Describe("#Fullscreen", func() {
+		BeforeEach(func() {
+			driver.GetWindowCall.ReturnWindow = window
+		})
+
+		It("makes the current window fullscreen", func() {
+			Expect(page.Fullscreen()).To(Succeed())
+			Expect(window.FullscreenCall.Called).To(BeTrue())
+		})
+
+		Context("when the window fails to become fullscreen", func() {
+			It("returns an error", func() {
+				window.FullscreenCall.Err = errors.New("some error")
+				Expect(page.Fullscreen()).To(MatchError("failed to make window fullscreen: some error"))
+			})
+		})
+	})
+
+	Describe("#SetViewport", func() {
+		BeforeEach(func() {
+			window.GetSizeCall.ReturnWidth = 800
+			window.GetSizeCall.ReturnHeight = 600
+			driver.GetWindowCall.ReturnWindow = window
+			driver.ExecuteCall.Result = `{"width": 780, "height": 500}`
+		})
+
+		It("sets the window size so that the viewport has the provided size", func() {
+			Expect(page.SetViewport(640, 480)).To(Succeed())
+			Expect(window.SizeCall.Width).To(Equal(660))
+			Expect(window.SizeCall.Height).To(Equal(580))
+		})
+
+		Context("when the viewport size cannot be retrieved", func() {
+			It("returns an error", func() {
+				driver.ExecuteCall.Err = errors.New("some error")
+				Expect(page.SetViewport(640, 480)).To(MatchError("failed to retrieve viewport size: some error"))
+			})
+		})
+
+		Context("when the window size cannot be retrieved", func() {
+			It("returns an error", func() {
+				window.GetSizeCall.Err = errors.New("some error")
+				Expect(page.SetViewport(640, 480)).To(MatchError("failed to retrieve window size: some error"))
+			})
+		})
+	})
+
+	Describe("#LocalStorage", func() {
+		It("returns the localStorage from the driver", func() {
+			storage := &mocks.Storage{}
+			storage.KeysCall.ReturnKeys = []string{"some-key"}
+			driver.GetLocalStorageCall.ReturnStorage = storage
+			Expect(page.LocalStorage().Keys()).To(Equal([]string{"some-key"}))
+		})
+	})
+
+	Describe("#SessionStorage", func() {
+		It("returns the sessionStorage from the driver", func() {
+			storage := &mocks.Storage{}
+			storage.ClearCall.Err = errors.New("some error")
+			driver.GetSessionStorageCall.ReturnStorage = storage
+			Expect(page.SessionStorage().Clear()).To(MatchError("failed to clear sessionStorage: some error"))
+		})
+	})
+
 	Describe("#URL", func() {
 		Context("when retrieving the URL is successful", func() {
 			var (
@@ -184,6 +426,195 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("window management", func() {
+		var (
+			firstWindow  *mocks.Window
+			secondWindow *mocks.Window
+		)
+
+		BeforeEach(func() {
+			firstWindow = &mocks.Window{ID: "some-window"}
+			secondWindow = &mocks.Window{ID: "some-other-window"}
+			driver.GetWindowCall.ReturnWindow = firstWindow
+			driver.GetWindowsCall.ReturnWindows = []types.Window{firstWindow, secondWindow}
+		})
+
+		Describe("#Windows", func() {
+			It("returns the handles of all windows", func() {
+				Expect(page.Windows()).To(Equal([]string{"some-window", "some-other-window"}))
+			})
+
+			Context("when the driver fails to retrieve the windows", func() {
+				It("returns an error", func() {
+					driver.GetWindowsCall.Err = errors.New("some error")
+					_, err := page.Windows()
+					Expect(err).To(MatchError("failed to retrieve windows: some error"))
+				})
+			})
+		})
+
+		Describe("#SwitchToWindow", func() {
+			It("switches to the window with the provided handle", func() {
+				Expect(page.SwitchToWindow("some-other-window")).To(Succeed())
+				Expect(driver.SetWindowCall.Window).To(Equal(secondWindow))
+			})
+
+			Context("when no window has the provided handle", func() {
+				It("returns an error", func() {
+					Expect(page.SwitchToWindow("some-missing-window")).To(MatchError("no window found with handle some-missing-window"))
+				})
+			})
+
+			Context("when the driver fails to switch windows", func() {
+				It("returns an error", func() {
+					driver.SetWindowCall.Err = errors.New("some error")
+					Expect(page.SwitchToWindow("some-window")).To(MatchError("failed to switch to window: some error"))
+				})
+			})
+		})
+
+		Describe("#SwitchToWindowByTitle", func() {
+			It("switches to a window with the provided title", func() {
+				driver.GetTitleCall.ReturnTitle = "some title"
+				Expect(page.SwitchToWindowByTitle("some title")).To(Succeed())
+				Expect(driver.SetWindowCall.Window).To(Equal(firstWindow))
+			})
+
+			Context("when no window has the provided title", func() {
+				BeforeEach(func() {
+					driver.GetWindowCall.ReturnWindow = secondWindow
+					driver.GetTitleCall.ReturnTitle = "some other title"
+				})
+
+				It("returns an error", func() {
+					Expect(page.SwitchToWindowByTitle("some title")).To(MatchError("no window found with title some title"))
+				})
+
+				It("switches back to the original window", func() {
+					page.SwitchToWindowByTitle("some title")
+					Expect(driver.SetWindowCall.Window).To(Equal(secondWindow))
+				})
+			})
+
+			Context("when the driver fails to retrieve a title", func() {
+				It("returns an error", func() {
+					driver.GetTitleCall.Err = errors.New("some error")
+					Expect(page.SwitchToWindowByTitle("some title")).To(MatchError("failed to retrieve window title: some error"))
+				})
+			})
+		})
+
+		Describe("#SwitchToWindowByURL", func() {
+			It("switches to a window with the provided URL", func() {
+				driver.GetURLCall.ReturnURL = "http://example.com"
+				Expect(page.SwitchToWindowByURL("http://example.com")).To(Succeed())
+			})
+
+			Context("when no window has the provided URL", func() {
+				It("returns an error", func() {
+					Expect(page.SwitchToWindowByURL("http://example.com")).To(MatchError("no window found with URL http://example.com"))
+				})
+			})
+		})
+
+		Describe("#NewWindow", func() {
+			BeforeEach(func() {
+				driver.NewWindowCall.ReturnWindow = secondWindow
+			})
+
+			It("opens a new window with the driver", func() {
+				handle, err := page.NewWindow()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handle).To(Equal("some-other-window"))
+				Expect(driver.NewWindowCall.Called).To(BeTrue())
+			})
+
+			It("switches to the new window", func() {
+				page.NewWindow()
+				Expect(driver.SetWindowCall.Window).To(Equal(secondWindow))
+			})
+
+			Context("when the driver fails to open a new window", func() {
+				It("returns an error", func() {
+					driver.NewWindowCall.Err = errors.New("some error")
+					_, err := page.NewWindow()
+					Expect(err).To(MatchError("failed to open new window: some error"))
+				})
+			})
+
+			Context("when the WebDriver does not provide the /window/new endpoint", func() {
+				BeforeEach(func() {
+					driver.NewWindowCall.Err = types.ErrUnknownCommand
+				})
+
+				It("opens a new window with a script", func() {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					page.WithContext(ctx).NewWindow()
+					Expect(driver.ExecuteCall.Body).To(Equal("window.open('about:blank');"))
+				})
+
+				Context("when the script fails", func() {
+					It("returns an error", func() {
+						driver.ExecuteCall.Err = errors.New("some error")
+						_, err := page.NewWindow()
+						Expect(err).To(MatchError("failed to open new window: some error"))
+					})
+				})
+			})
+		})
+
+		Describe("#CloseWindow", func() {
+			It("closes the current window", func() {
+				Expect(page.CloseWindow()).To(Succeed())
+				Expect(driver.DeleteWindowCall.Called).To(BeTrue())
+			})
+
+			Context("when the driver fails to close the window", func() {
+				It("returns an error", func() {
+					driver.DeleteWindowCall.Err = errors.New("some error")
+					Expect(page.CloseWindow()).To(MatchError("failed to close window: some error"))
+				})
+			})
+		})
+
+		Describe("#WaitForNewWindow", func() {
+			It("returns the handle of the window opened by the action", func() {
+				newWindow := &mocks.Window{ID: "some-new-window"}
+				handle, err := page.WaitForNewWindow(time.Second, func() error {
+					driver.GetWindowsCall.ReturnWindows = []types.Window{firstWindow, newWindow, secondWindow}
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handle).To(Equal("some-new-window"))
+			})
+
+			Context("when the action fails", func() {
+				It("returns the action error", func() {
+					_, err := page.WaitForNewWindow(time.Second, func() error { return errors.New("some error") })
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+
+			Context("when no window is opened before the timeout", func() {
+				It("returns an error", func() {
+					_, err := page.WaitForNewWindow(0, func() error { return nil })
+					Expect(err).To(MatchError("no new window was opened"))
+				})
+			})
+
+			Context("when the context of the page is done", func() {
+				It("returns an error without waiting for the timeout", func() {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					_, err := page.WithContext(ctx).WaitForNewWindow(time.Hour, func() error { return nil })
+					Expect(err).To(MatchError("no new window was opened: context canceled"))
+					Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+				})
+			})
+		})
+	})
+
 	Describe("#Screenshot", func() {
 		var filename string
 
@@ -250,6 +681,45 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#ScreenshotImage", func() {
+		It("returns the decoded screenshot", func() {
+			screenshot := &bytes.Buffer{}
+			png.Encode(screenshot, image.NewRGBA(image.Rect(0, 0, 20, 10)))
+			driver.GetScreenshotCall.ReturnImage = screenshot.Bytes()
+			pageImage, err := page.ScreenshotImage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pageImage.Bounds()).To(Equal(image.Rect(0, 0, 20, 10)))
+		})
+
+		Context("when selections are provided to highlight", func() {
+			BeforeEach(func() {
+				driver.GetElementsCall.ReturnElements = []types.Element{element}
+			})
+
+			It("removes the highlight after the capture", func() {
+				page.ScreenshotImage(page.Find("#selector"))
+				Expect(driver.ExecuteCall.Body).To(ContainSubstring(`removeAttribute("data-agouti-outline")`))
+			})
+
+			Context("when a selection cannot be highlighted", func() {
+				It("returns an error without retrieving a screenshot", func() {
+					driver.ExecuteCall.Err = errors.New("some error")
+					driver.GetScreenshotCall.Err = errors.New("some other error")
+					_, err := page.ScreenshotImage(page.Find("#selector"))
+					Expect(err).To(MatchError("failed to highlight 'CSS: #selector': some error"))
+				})
+			})
+		})
+
+		Context("when the screenshot is not a PNG image", func() {
+			It("returns an error", func() {
+				driver.GetScreenshotCall.ReturnImage = []byte("some-image")
+				_, err := page.ScreenshotImage()
+				Expect(err.Error()).To(HavePrefix("failed to decode screenshot: "))
+			})
+		})
+	})
+
 	Describe("#Title", func() {
 		Context("when retrieving the page title is successful", func() {
 			var (
@@ -351,6 +821,75 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#RunAsyncScript", func() {
+		var result struct{ Some string }
+
+		It("provides the driver with a javascript function that is passed the arguments and callback", func() {
+			page.RunAsyncScript("some javascript code", map[string]interface{}{"argument": "value"}, &result)
+			Expect(driver.ExecuteAsyncCall.Body).To(ContainSubstring("(function(argument, callback) { some javascript code; }).apply(this, arguments);"))
+			Expect(driver.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{"value"}))
+		})
+
+		It("does not wait for returned promises", func() {
+			page.RunAsyncScript("some javascript code", nil, &result)
+			Expect(driver.ExecuteAsyncCall.Body).NotTo(ContainSubstring("then("))
+		})
+
+		It("unmarshalls the returned result into the provided result interface", func() {
+			driver.ExecuteAsyncCall.Result = `{"some": "result"}`
+			Expect(page.RunAsyncScript("some javascript code", nil, &result)).To(Succeed())
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		Context("when the result cannot be parsed", func() {
+			It("returns an error", func() {
+				driver.ExecuteAsyncCall.Result = `"some string"`
+				err := page.RunAsyncScript("some javascript code", nil, &result)
+				Expect(err.Error()).To(HavePrefix("failed to parse script result: "))
+			})
+		})
+
+		Context("when running the script fails", func() {
+			It("returns an error", func() {
+				driver.ExecuteAsyncCall.Err = errors.New("some error")
+				err := page.RunAsyncScript("some javascript code", nil, &result)
+				Expect(err).To(MatchError("failed to run script: some error"))
+			})
+		})
+	})
+
+	Describe("#AwaitScript", func() {
+		var result struct{ Some string }
+
+		It("provides the driver with a javascript function that is passed the arguments and resolves the callback with its result", func() {
+			page.AwaitScript("some javascript code", map[string]interface{}{"argument": "value"}, &result)
+			Expect(driver.ExecuteAsyncCall.Body).To(ContainSubstring("Promise.resolve((function(argument) { some javascript code; }).apply(this, arguments)).then(callback, "))
+			Expect(driver.ExecuteAsyncCall.Arguments).To(Equal([]interface{}{"value"}))
+		})
+
+		It("unmarshalls the resolved result into the provided result interface", func() {
+			driver.ExecuteAsyncCall.Result = `{"some": "result"}`
+			Expect(page.AwaitScript("some javascript code", nil, &result)).To(Succeed())
+			Expect(result.Some).To(Equal("result"))
+		})
+
+		Context("when the returned promise is rejected", func() {
+			It("returns an error with the reason", func() {
+				driver.ExecuteAsyncCall.Result = `{"agoutiRejection": "Error: some reason"}`
+				err := page.AwaitScript("some javascript code", nil, &result)
+				Expect(err).To(MatchError("failed to run script: promise rejected: Error: some reason"))
+			})
+		})
+
+		Context("when running the script fails", func() {
+			It("returns an error", func() {
+				driver.ExecuteAsyncCall.Err = errors.New("some error")
+				err := page.AwaitScript("some javascript code", nil, &result)
+				Expect(err).To(MatchError("failed to run script: some error"))
+			})
+		})
+	})
+
 	Describe("#Forward", func() {
 		It("instructs the driver to move forward in history", func() {
 			page.Forward()
@@ -417,6 +956,106 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#PopupText", func() {
+		It("returns the text of the open popup", func() {
+			driver.GetAlertTextCall.ReturnText = "some text"
+			Expect(page.PopupText()).To(Equal("some text"))
+		})
+
+		Context("when the driver fails to retrieve the popup text", func() {
+			It("returns an error", func() {
+				driver.GetAlertTextCall.Err = errors.New("some error")
+				_, err := page.PopupText()
+				Expect(err).To(MatchError("failed to retrieve popup text: some error"))
+			})
+		})
+	})
+
+	Describe("#EnterPopupText", func() {
+		It("enters the provided text into the popup", func() {
+			Expect(page.EnterPopupText("some text")).To(Succeed())
+			Expect(driver.SetAlertTextCall.Text).To(Equal("some text"))
+		})
+
+		Context("when the driver fails to enter the text", func() {
+			It("returns an error", func() {
+				driver.SetAlertTextCall.Err = errors.New("some error")
+				Expect(page.EnterPopupText("some text")).To(MatchError("failed to enter popup text: some error"))
+			})
+		})
+	})
+
+	Describe("#ConfirmPopup", func() {
+		It("accepts the popup", func() {
+			Expect(page.ConfirmPopup()).To(Succeed())
+			Expect(driver.AcceptAlertCall.Called).To(BeTrue())
+		})
+
+		Context("when the driver fails to accept the popup", func() {
+			It("returns an error", func() {
+				driver.AcceptAlertCall.Err = errors.New("some error")
+				Expect(page.ConfirmPopup()).To(MatchError("failed to confirm popup: some error"))
+			})
+		})
+	})
+
+	Describe("#CancelPopup", func() {
+		It("dismisses the popup", func() {
+			Expect(page.CancelPopup()).To(Succeed())
+			Expect(driver.DismissAlertCall.Called).To(BeTrue())
+		})
+
+		Context("when the driver fails to dismiss the popup", func() {
+			It("returns an error", func() {
+				driver.DismissAlertCall.Err = errors.New("some error")
+				Expect(page.CancelPopup()).To(MatchError("failed to cancel popup: some error"))
+			})
+		})
+	})
+
+	Describe("#Frame", func() {
+		It("returns a selection within the selected frame", func() {
+			Expect(page.Frame("iframe#pay").Find("input").String()).To(Equal("Frame: CSS: iframe#pay | CSS: input"))
+		})
+	})
+
+	Describe("#SwitchToFrame", func() {
+		It("switches to the frame element referred to by the selection", func() {
+			frameElement := &mocks.Element{}
+			driver.GetElementsCall.ReturnElements = []types.Element{frameElement}
+			Expect(page.SwitchToFrame(page.Find("iframe"))).To(Succeed())
+			Expect(driver.SetFrameCall.Frame).To(Equal(frameElement))
+		})
+	})
+
+	Describe("#SwitchToParentFrame", func() {
+		It("switches to the parent frame", func() {
+			Expect(page.SwitchToParentFrame()).To(Succeed())
+			Expect(driver.SetParentFrameCall.Called).To(BeTrue())
+		})
+
+		Context("when the driver fails to switch frames", func() {
+			It("returns an error", func() {
+				driver.SetParentFrameCall.Err = errors.New("some error")
+				Expect(page.SwitchToParentFrame()).To(MatchError("failed to switch to parent frame: some error"))
+			})
+		})
+	})
+
+	Describe("#SwitchToRootFrame", func() {
+		It("switches to the top-level browsing context", func() {
+			Expect(page.SwitchToRootFrame()).To(Succeed())
+			Expect(driver.SetFrameCall.Frames).To(Equal([]types.Element{nil}))
+		})
+
+		Context("when the driver fails to switch frames", func() {
+			It("returns an error", func() {
+				driver.SetFrameCall.Err = errors.New("some error")
+				Expect(page.SwitchToRootFrame()).To(MatchError("failed to switch to root frame: some error"))
+			})
+		})
+	})
+
 	Describe("#Find", func() {
 		It("returns a selection", func() {
 			Expect(page.Find("#selector").String()).To(Equal("CSS: #selector"))
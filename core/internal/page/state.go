@@ -0,0 +1,153 @@
+package page
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+	"io"
+	neturl "net/url"
+	"strings"
+)
+
+const readStorageScript = `var read = function(storage) {
+	var items = {};
+	for (var i = 0; i < storage.length; i++) {
+		var key = storage.key(i);
+		items[key] = storage.getItem(key);
+	}
+	return items;
+};
+return {localStorage: read(window.localStorage), sessionStorage: read(window.sessionStorage)};`
+
+const writeStorageScript = `var write = function(storage, items) {
+	for (var key in items) {
+		storage.setItem(key, items[key]);
+	}
+};
+write(window.localStorage, arguments[0]);
+write(window.sessionStorage, arguments[1]);`
+
+type browserState struct {
+	URL     string                     `json:"url"`
+	Cookies map[string][]*types.Cookie `json:"cookies"`
+	Origins map[string]*originStorage  `json:"origins"`
+}
+
+type originStorage struct {
+	LocalStorage   map[string]string `json:"localStorage"`
+	SessionStorage map[string]string `json:"sessionStorage"`
+}
+
+// SaveState writes the URL, cookies (grouped by domain), localStorage and
+// sessionStorage of the current page to w as a JSON document.
+func (p *Page) SaveState(w io.Writer) error {
+	url, err := p.Driver.GetURL()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve URL: %w", err)
+	}
+
+	cookies, err := p.Driver.GetCookies()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve cookies: %w", err)
+	}
+
+	storage := &originStorage{}
+	if err := p.Driver.Execute(readStorageScript, nil, storage); err != nil {
+		return fmt.Errorf("failed to retrieve storage: %w", err)
+	}
+
+	state := &browserState{URL: url, Cookies: map[string][]*types.Cookie{}, Origins: map[string]*originStorage{}}
+	for _, cookie := range cookies {
+		state.Cookies[cookie.Domain] = append(state.Cookies[cookie.Domain], cookie)
+	}
+
+	if origin, err := originOf(url); err == nil {
+		state.Origins[origin] = storage
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+
+	return nil
+}
+
+// LoadState restores a document written by SaveState. As cookies and storage
+// can only be set on a matching domain, it navigates to each domain before
+// restoring them, and finally navigates to the saved URL.
+func (p *Page) LoadState(r io.Reader) error {
+	var state browserState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("failed to read state: %w", err)
+	}
+
+	savedURL, err := neturl.Parse(state.URL)
+	if err != nil {
+		return fmt.Errorf("failed to read state: invalid URL: %w", err)
+	}
+
+	current := ""
+	navigate := func(url string) error {
+		if url == current {
+			return nil
+		}
+		current = url
+		if err := p.Driver.SetURL(url); err != nil {
+			return fmt.Errorf("failed to navigate to %s: %w", url, err)
+		}
+		return nil
+	}
+
+	for domain, cookies := range state.Cookies {
+		if err := navigate(cookieDomainURL(savedURL, domain)); err != nil {
+			return err
+		}
+
+		for _, cookie := range cookies {
+			if err := p.Driver.SetCookie(cookie); err != nil {
+				return fmt.Errorf("failed to set cookie %s: %w", cookie.Name, err)
+			}
+		}
+	}
+
+	for origin, storage := range state.Origins {
+		if err := navigate(origin + "/"); err != nil {
+			return err
+		}
+
+		arguments := []interface{}{storage.LocalStorage, storage.SessionStorage}
+		if err := p.Driver.Execute(writeStorageScript, arguments, &struct{}{}); err != nil {
+			return fmt.Errorf("failed to set storage for %s: %w", origin, err)
+		}
+	}
+
+	if err := p.Driver.SetURL(state.URL); err != nil {
+		return fmt.Errorf("failed to navigate to %s: %w", state.URL, err)
+	}
+
+	return nil
+}
+
+func originOf(url string) (string, error) {
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("%s has no origin", url)
+	}
+	return parsed.Scheme + "://" + parsed.Host, nil
+}
+
+// cookieDomainURL returns a URL on which cookies for domain may be set,
+// preferring the saved URL when it is on that domain.
+func cookieDomainURL(savedURL *neturl.URL, domain string) string {
+	domain = strings.TrimPrefix(domain, ".")
+	host := savedURL.Hostname()
+	if domain == "" || host == domain || strings.HasSuffix(host, "."+domain) {
+		return savedURL.Scheme + "://" + savedURL.Host + "/"
+	}
+	return savedURL.Scheme + "://" + domain + "/"
+}
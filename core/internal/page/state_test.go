@@ -0,0 +1,116 @@
+package page_test
+
+import (
+	"bytes"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/page"
+	"github.com/sclevine/agouti/core/internal/types"
+	"strings"
+)
+
+var _ = Describe("Page state", func() {
+	var (
+		page   *Page
+		driver *mocks.Driver
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		page = &Page{Driver: driver}
+	})
+
+	Describe("#SaveState", func() {
+		BeforeEach(func() {
+			driver.GetURLCall.ReturnURL = "https://www.example.com/some/path"
+			driver.GetCookiesCall.ReturnCookies = []*types.Cookie{
+				{Name: "some-name", Value: "some-value", Domain: ".example.com", Path: "/"},
+				{Name: "some-other-name", Value: "some-other-value", Domain: "www.example.com", Path: "/"},
+			}
+			driver.ExecuteCall.Result = `{"localStorage": {"some-key": "some-value"}, "sessionStorage": {"some-other-key": "some-other-value"}}`
+		})
+
+		It("writes the URL, cookies grouped by domain and storage of the current origin", func() {
+			output := &bytes.Buffer{}
+			Expect(page.SaveState(output)).To(Succeed())
+			Expect(output.String()).To(MatchJSON(`{
+				"url": "https://www.example.com/some/path",
+				"cookies": {
+					".example.com": [{"name": "some-name", "value": "some-value", "path": "/", "domain": ".example.com", "secure": false, "httpOnly": false}],
+					"www.example.com": [{"name": "some-other-name", "value": "some-other-value", "path": "/", "domain": "www.example.com", "secure": false, "httpOnly": false}]
+				},
+				"origins": {
+					"https://www.example.com": {
+						"localStorage": {"some-key": "some-value"},
+						"sessionStorage": {"some-other-key": "some-other-value"}
+					}
+				}
+			}`))
+		})
+
+		Context("when the driver fails to retrieve the cookies", func() {
+			It("returns an error", func() {
+				driver.GetCookiesCall.Err = errors.New("some error")
+				Expect(page.SaveState(&bytes.Buffer{})).To(MatchError("failed to retrieve cookies: some error"))
+			})
+		})
+
+		Context("when the driver fails to retrieve the storage", func() {
+			It("returns an error", func() {
+				driver.ExecuteCall.Err = errors.New("some error")
+				Expect(page.SaveState(&bytes.Buffer{})).To(MatchError("failed to retrieve storage: some error"))
+			})
+		})
+	})
+
+	Describe("#LoadState", func() {
+		var state string
+
+		BeforeEach(func() {
+			state = `{
+				"url": "https://www.example.com/some/path",
+				"cookies": {".example.com": [{"name": "some-name", "value": "some-value", "domain": ".example.com"}]},
+				"origins": {"https://www.example.com": {"localStorage": {"some-key": "some-value"}, "sessionStorage": {}}}
+			}`
+		})
+
+		It("sets the saved cookies", func() {
+			Expect(page.LoadState(strings.NewReader(state))).To(Succeed())
+			Expect(driver.SetCookieCall.Cookie).To(Equal(&types.Cookie{Name: "some-name", Value: "some-value", Domain: ".example.com"}))
+		})
+
+		It("sets the saved storage", func() {
+			page.LoadState(strings.NewReader(state))
+			Expect(driver.ExecuteCall.Body).To(ContainSubstring("storage.setItem(key, items[key])"))
+			Expect(driver.ExecuteCall.Arguments).To(Equal([]interface{}{map[string]string{"some-key": "some-value"}, map[string]string{}}))
+		})
+
+		It("navigates to the saved URL", func() {
+			page.LoadState(strings.NewReader(state))
+			Expect(driver.SetURLCall.URL).To(Equal("https://www.example.com/some/path"))
+		})
+
+		Context("when a cookie cannot be set", func() {
+			It("returns an error", func() {
+				driver.SetCookieCall.Err = errors.New("some error")
+				Expect(page.LoadState(strings.NewReader(state))).To(MatchError("failed to set cookie some-name: some error"))
+			})
+		})
+
+		Context("when navigation fails", func() {
+			It("returns an error", func() {
+				driver.SetURLCall.Err = errors.New("some error")
+				Expect(page.LoadState(strings.NewReader(state))).To(MatchError("failed to navigate to https://www.example.com/: some error"))
+			})
+		})
+
+		Context("when the state is not valid JSON", func() {
+			It("returns an error", func() {
+				err := page.LoadState(strings.NewReader("{"))
+				Expect(err.Error()).To(HavePrefix("failed to read state: "))
+			})
+		})
+	})
+})
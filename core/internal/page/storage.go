@@ -0,0 +1,51 @@
+package page
+
+import (
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+)
+
+// Storage is the localStorage or sessionStorage of the origin of the current page.
+// Name is used to describe errors, and is either "localStorage" or "sessionStorage".
+type Storage struct {
+	Name    string
+	Storage types.Storage
+}
+
+// Get returns the value stored for key, or an empty string if nothing is stored.
+func (s *Storage) Get(key string) (string, error) {
+	value, err := s.Storage.Get(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve %s item %s: %w", s.Name, key, err)
+	}
+	return value, nil
+}
+
+func (s *Storage) Set(key, value string) error {
+	if err := s.Storage.Set(key, value); err != nil {
+		return fmt.Errorf("failed to set %s item %s: %w", s.Name, key, err)
+	}
+	return nil
+}
+
+func (s *Storage) Delete(key string) error {
+	if err := s.Storage.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete %s item %s: %w", s.Name, key, err)
+	}
+	return nil
+}
+
+func (s *Storage) Keys() ([]string, error) {
+	keys, err := s.Storage.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s keys: %w", s.Name, err)
+	}
+	return keys, nil
+}
+
+func (s *Storage) Clear() error {
+	if err := s.Storage.Clear(); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", s.Name, err)
+	}
+	return nil
+}
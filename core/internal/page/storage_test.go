@@ -0,0 +1,88 @@
+package page_test
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/page"
+)
+
+var _ = Describe("Storage", func() {
+	var (
+		storage       *Storage
+		driverStorage *mocks.Storage
+	)
+
+	BeforeEach(func() {
+		driverStorage = &mocks.Storage{}
+		storage = &Storage{"localStorage", driverStorage}
+	})
+
+	Describe("#Get", func() {
+		It("returns the value stored for the key", func() {
+			driverStorage.GetCall.ReturnValue = "some value"
+			Expect(storage.Get("some-key")).To(Equal("some value"))
+			Expect(driverStorage.GetCall.Key).To(Equal("some-key"))
+		})
+
+		Context("when the driver fails to retrieve the value", func() {
+			It("returns an error", func() {
+				driverStorage.GetCall.Err = errors.New("some error")
+				_, err := storage.Get("some-key")
+				Expect(err).To(MatchError("failed to retrieve localStorage item some-key: some error"))
+			})
+		})
+	})
+
+	Describe("#Set", func() {
+		It("stores the value for the key", func() {
+			Expect(storage.Set("some-key", "some value")).To(Succeed())
+			Expect(driverStorage.SetCall.Key).To(Equal("some-key"))
+			Expect(driverStorage.SetCall.Value).To(Equal("some value"))
+		})
+
+		Context("when the driver fails to store the value", func() {
+			It("returns an error", func() {
+				driverStorage.SetCall.Err = errors.New("some error")
+				Expect(storage.Set("some-key", "some value")).To(MatchError("failed to set localStorage item some-key: some error"))
+			})
+		})
+	})
+
+	Describe("#Delete", func() {
+		It("deletes the key", func() {
+			Expect(storage.Delete("some-key")).To(Succeed())
+			Expect(driverStorage.DeleteCall.Key).To(Equal("some-key"))
+		})
+
+		Context("when the driver fails to delete the key", func() {
+			It("returns an error", func() {
+				driverStorage.DeleteCall.Err = errors.New("some error")
+				Expect(storage.Delete("some-key")).To(MatchError("failed to delete localStorage item some-key: some error"))
+			})
+		})
+	})
+
+	Describe("#Keys", func() {
+		It("returns the stored keys", func() {
+			driverStorage.KeysCall.ReturnKeys = []string{"some-key", "some-other-key"}
+			Expect(storage.Keys()).To(Equal([]string{"some-key", "some-other-key"}))
+		})
+
+		Context("when the driver fails to retrieve the keys", func() {
+			It("returns an error", func() {
+				driverStorage.KeysCall.Err = errors.New("some error")
+				_, err := storage.Keys()
+				Expect(err).To(MatchError("failed to retrieve localStorage keys: some error"))
+			})
+		})
+	})
+
+	Describe("#Clear", func() {
+		It("clears the storage", func() {
+			Expect(storage.Clear()).To(Succeed())
+			Expect(driverStorage.ClearCall.Called).To(BeTrue())
+		})
+	})
+})
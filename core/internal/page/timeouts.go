@@ -0,0 +1,55 @@
+package page
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetPageLoadTimeout sets how long Navigate, Back, Forward and Refresh wait for a page to load.
+func (p *Page) SetPageLoadTimeout(timeout time.Duration) error {
+	return p.setTimeout("page load", timeout)
+}
+
+// SetScriptTimeout sets how long RunAsyncScript waits for a script to finish.
+func (p *Page) SetScriptTimeout(timeout time.Duration) error {
+	return p.setTimeout("script", timeout)
+}
+
+// SetImplicitWait sets how long the WebDriver waits for elements to appear
+// when a selection is used.
+func (p *Page) SetImplicitWait(timeout time.Duration) error {
+	return p.setTimeout("implicit", timeout)
+}
+
+// PageLoadTimeout returns the timeout set by SetPageLoadTimeout.
+// Only W3C WebDrivers report their timeouts.
+func (p *Page) PageLoadTimeout() (time.Duration, error) {
+	return p.timeout("page load")
+}
+
+// ScriptTimeout returns the timeout set by SetScriptTimeout, or zero when
+// scripts never time out. Only W3C WebDrivers report their timeouts.
+func (p *Page) ScriptTimeout() (time.Duration, error) {
+	return p.timeout("script")
+}
+
+// ImplicitWait returns the timeout set by SetImplicitWait.
+// Only W3C WebDrivers report their timeouts.
+func (p *Page) ImplicitWait() (time.Duration, error) {
+	return p.timeout("implicit")
+}
+
+func (p *Page) setTimeout(timeoutType string, timeout time.Duration) error {
+	if err := p.Driver.SetTimeout(timeoutType, timeout); err != nil {
+		return fmt.Errorf("failed to set %s timeout: %w", timeoutType, err)
+	}
+	return nil
+}
+
+func (p *Page) timeout(timeoutType string) (time.Duration, error) {
+	timeout, err := p.Driver.GetTimeout(timeoutType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve %s timeout: %w", timeoutType, err)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,99 @@
+package page_test
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/page"
+	"time"
+)
+
+var _ = Describe("Timeouts", func() {
+	var (
+		page   *Page
+		driver *mocks.Driver
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		page = &Page{Driver: driver}
+	})
+
+	Describe("#SetPageLoadTimeout", func() {
+		It("sets the page load timeout", func() {
+			Expect(page.SetPageLoadTimeout(3 * time.Second)).To(Succeed())
+			Expect(driver.SetTimeoutCall.Type).To(Equal("page load"))
+			Expect(driver.SetTimeoutCall.Timeout).To(Equal(3 * time.Second))
+		})
+
+		Context("when the driver fails to set the timeout", func() {
+			It("returns an error", func() {
+				driver.SetTimeoutCall.Err = errors.New("some error")
+				Expect(page.SetPageLoadTimeout(time.Second)).To(MatchError("failed to set page load timeout: some error"))
+			})
+		})
+	})
+
+	Describe("#SetScriptTimeout", func() {
+		It("sets the script timeout", func() {
+			Expect(page.SetScriptTimeout(2 * time.Second)).To(Succeed())
+			Expect(driver.SetTimeoutCall.Type).To(Equal("script"))
+			Expect(driver.SetTimeoutCall.Timeout).To(Equal(2 * time.Second))
+		})
+
+		Context("when the driver fails to set the timeout", func() {
+			It("returns an error", func() {
+				driver.SetTimeoutCall.Err = errors.New("some error")
+				Expect(page.SetScriptTimeout(time.Second)).To(MatchError("failed to set script timeout: some error"))
+			})
+		})
+	})
+
+	Describe("#SetImplicitWait", func() {
+		It("sets the implicit timeout", func() {
+			Expect(page.SetImplicitWait(time.Second)).To(Succeed())
+			Expect(driver.SetTimeoutCall.Type).To(Equal("implicit"))
+			Expect(driver.SetTimeoutCall.Timeout).To(Equal(time.Second))
+		})
+
+		Context("when the driver fails to set the timeout", func() {
+			It("returns an error", func() {
+				driver.SetTimeoutCall.Err = errors.New("some error")
+				Expect(page.SetImplicitWait(time.Second)).To(MatchError("failed to set implicit timeout: some error"))
+			})
+		})
+	})
+
+	Describe("#PageLoadTimeout", func() {
+		It("returns the page load timeout", func() {
+			driver.GetTimeoutCall.ReturnTimeout = 3 * time.Second
+			Expect(page.PageLoadTimeout()).To(Equal(3 * time.Second))
+			Expect(driver.GetTimeoutCall.Type).To(Equal("page load"))
+		})
+
+		Context("when the driver fails to retrieve the timeout", func() {
+			It("returns an error", func() {
+				driver.GetTimeoutCall.Err = errors.New("some error")
+				_, err := page.PageLoadTimeout()
+				Expect(err).To(MatchError("failed to retrieve page load timeout: some error"))
+			})
+		})
+	})
+
+	Describe("#ScriptTimeout", func() {
+		It("returns the script timeout", func() {
+			driver.GetTimeoutCall.ReturnTimeout = 2 * time.Second
+			Expect(page.ScriptTimeout()).To(Equal(2 * time.Second))
+			Expect(driver.GetTimeoutCall.Type).To(Equal("script"))
+		})
+	})
+
+	Describe("#ImplicitWait", func() {
+		It("returns the implicit timeout", func() {
+			driver.GetTimeoutCall.ReturnTimeout = time.Second
+			Expect(page.ImplicitWait()).To(Equal(time.Second))
+			Expect(driver.GetTimeoutCall.Type).To(Equal("implicit"))
+		})
+	})
+})
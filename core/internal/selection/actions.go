@@ -3,66 +3,88 @@ package selection
 import "fmt"
 
 func (s *Selection) Click() error {
+	return s.Wait.retry(s.click)
+}
+
+func (s *Selection) click() error {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	if err := element.Click(); err != nil {
-		return fmt.Errorf("failed to click on '%s': %s", s, err)
+		return fmt.Errorf("failed to click on '%s': %w", s, err)
 	}
 	return nil
 }
 
 func (s *Selection) DoubleClick() error {
+	return s.Wait.retry(s.doubleClick)
+}
+
+func (s *Selection) doubleClick() error {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	if err := s.Driver.MoveTo(element, nil); err != nil {
-		return fmt.Errorf("failed to move mouse to '%s': %s", s, err)
+		return fmt.Errorf("failed to move mouse to '%s': %w", s, err)
 	}
 
 	if err := s.Driver.DoubleClick(); err != nil {
-		return fmt.Errorf("failed to double-click on '%s': %s", s, err)
+		return fmt.Errorf("failed to double-click on '%s': %w", s, err)
 	}
 	return nil
 }
 
 func (s *Selection) Fill(text string) error {
+	return s.Wait.retry(func() error {
+		return s.fill(text)
+	})
+}
+
+func (s *Selection) fill(text string) error {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	if err := element.Clear(); err != nil {
-		return fmt.Errorf("failed to clear '%s': %s", s, err)
+		return fmt.Errorf("failed to clear '%s': %w", s, err)
 	}
 
 	if err := element.Value(text); err != nil {
-		return fmt.Errorf("failed to enter text into '%s': %s", s, err)
+		return fmt.Errorf("failed to enter text into '%s': %w", s, err)
 	}
 	return nil
 }
 
 func (s *Selection) Check() error {
-	return s.setChecked(true)
+	return s.Wait.retry(func() error {
+		return s.setChecked(true)
+	})
 }
 
 func (s *Selection) Uncheck() error {
-	return s.setChecked(false)
+	return s.Wait.retry(func() error {
+		return s.setChecked(false)
+	})
 }
 
 func (s *Selection) setChecked(checked bool) error {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	elementType, err := element.GetAttribute("type")
 	if err != nil {
-		return fmt.Errorf("failed to retrieve type of '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve type of '%s': %w", s, err)
 	}
 
 	if elementType != "checkbox" {
@@ -71,12 +93,12 @@ func (s *Selection) setChecked(checked bool) error {
 
 	selected, err := element.IsSelected()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve state of '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve state of '%s': %w", s, err)
 	}
 
 	if selected != checked {
 		if err := element.Click(); err != nil {
-			return fmt.Errorf("failed to click on '%s': %s", s, err)
+			return fmt.Errorf("failed to click on '%s': %w", s, err)
 		}
 	}
 
@@ -84,20 +106,27 @@ func (s *Selection) setChecked(checked bool) error {
 }
 
 func (s *Selection) Select(text string) error {
+	return s.Wait.retry(func() error {
+		return s.selectOption(text)
+	})
+}
+
+func (s *Selection) selectOption(text string) error {
+	defer s.leaveFrame()
 	elements, err := s.Find("option").(*Selection).getElements()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve options for '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve options for '%s': %w", s, err)
 	}
 
 	for _, element := range elements {
 		elementText, err := element.GetText()
 		if err != nil {
-			return fmt.Errorf("failed to retrieve option text for '%s': %s", s, err)
+			return fmt.Errorf("failed to retrieve option text for '%s': %w", s, err)
 		}
 
 		if elementText == text {
 			if err := element.Click(); err != nil {
-				return fmt.Errorf(`failed to click on option with text "%s" for '%s': %s`, elementText, s, err)
+				return fmt.Errorf(`failed to click on option with text "%s" for '%s': %w`, elementText, s, err)
 			}
 			return nil
 		}
@@ -107,13 +136,18 @@ func (s *Selection) Select(text string) error {
 }
 
 func (s *Selection) Submit() error {
+	return s.Wait.retry(s.submit)
+}
+
+func (s *Selection) submit() error {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	if err := element.Submit(); err != nil {
-		return fmt.Errorf("failed to submit '%s': %s", s, err)
+		return fmt.Errorf("failed to submit '%s': %w", s, err)
 	}
 	return nil
 }
@@ -0,0 +1,138 @@
+package selection
+
+import (
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+)
+
+const attributesScript = `var name = arguments[1];
+return arguments[0].map(function(element) {
+	var property = element[name];
+	if (property !== undefined && property !== null && typeof property !== "object" && typeof property !== "function") {
+		return String(property);
+	}
+	var attribute = element.getAttribute(name);
+	return attribute === null ? "" : attribute;
+});`
+
+const cssValuesScript = `var property = arguments[1];
+return arguments[0].map(function(element) {
+	return window.getComputedStyle(element).getPropertyValue(property);
+});`
+
+// displayedFunction approximates the isDisplayed check of WebDriver, so that
+// elements read together are treated as they are when read one at a time.
+const displayedFunction = `function displayed(element) {
+	if (element.tagName === "OPTION" || element.tagName === "OPTGROUP") {
+		var select = element.closest("select");
+		if (select) return displayed(select);
+	}
+	var style = window.getComputedStyle(element);
+	if (style.visibility === "hidden" || style.visibility === "collapse") return false;
+	for (var ancestor = element; ancestor; ancestor = ancestor.parentElement) {
+		if (window.getComputedStyle(ancestor).opacity === "0") return false;
+	}
+	return element.getClientRects().length > 0;
+}`
+
+// textsScript only reads the rendered text of displayed elements, as WebDriver
+// reports no text for elements that are not displayed.
+const textsScript = displayedFunction + `
+return arguments[0].map(function(element) {
+	return displayed(element) ? element.innerText.trim() : "";
+});`
+
+const visibilitiesScript = displayedFunction + `
+return arguments[0].map(displayed);`
+
+// Texts returns the text of each element referred to by the selection.
+func (s *Selection) Texts() (texts []string, err error) {
+	err = s.Wait.retry(func() error {
+		texts = []string{}
+		return s.readAll("text", textsScript, nil, &texts, func(element types.Element) error {
+			text, err := element.GetText()
+			texts = append(texts, text)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return texts, nil
+}
+
+// Attributes returns the value of the provided attribute of each element
+// referred to by the selection.
+func (s *Selection) Attributes(attribute string) (values []string, err error) {
+	err = s.Wait.retry(func() error {
+		values = []string{}
+		return s.readAll("attribute value", attributesScript, attribute, &values, func(element types.Element) error {
+			value, err := element.GetAttribute(attribute)
+			values = append(values, value)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// CSSValues returns the computed value of the provided CSS property of each
+// element referred to by the selection.
+func (s *Selection) CSSValues(property string) (values []string, err error) {
+	err = s.Wait.retry(func() error {
+		values = []string{}
+		return s.readAll("CSS property", cssValuesScript, property, &values, func(element types.Element) error {
+			value, err := element.GetCSS(property)
+			values = append(values, value)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Visibilities returns whether each element referred to by the selection is
+// displayed on the page.
+func (s *Selection) Visibilities() (visibilities []bool, err error) {
+	err = s.Wait.retry(func() error {
+		visibilities = []bool{}
+		return s.readAll("visibility", visibilitiesScript, nil, &visibilities, func(element types.Element) error {
+			visible, err := element.IsDisplayed()
+			visibilities = append(visibilities, visible)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return visibilities, nil
+}
+
+// readAll reads a property of every selected element into result with a single
+// script. When the script cannot be run, it calls read for each element instead.
+func (s *Selection) readAll(property, script string, argument, result interface{}, read func(element types.Element) error) error {
+	defer s.leaveFrame()
+	elements, err := s.getElements()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve elements for '%s': %w", s, err)
+	}
+
+	if len(elements) == 0 {
+		return nil
+	}
+
+	if err := s.Driver.Execute(script, []interface{}{elements, argument}, result); err == nil {
+		return nil
+	}
+
+	for _, element := range elements {
+		if err := read(element); err != nil {
+			return fmt.Errorf("failed to retrieve %s for '%s': %w", property, s, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package selection_test
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/selection"
+	"github.com/sclevine/agouti/core/internal/types"
+)
+
+var _ = Describe("Bulk properties", func() {
+	var (
+		selection     types.Selection
+		driver        *mocks.Driver
+		firstElement  *mocks.Element
+		secondElement *mocks.Element
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		firstElement = &mocks.Element{}
+		secondElement = &mocks.Element{}
+		driver.GetElementsCall.ReturnElements = []types.Element{firstElement, secondElement}
+		selection = (&Selection{Driver: driver}).Find("li")
+	})
+
+	Describe("#Texts", func() {
+		It("reads the text of every element with a single script", func() {
+			driver.ExecuteCall.Result = `["some text", "some other text"]`
+			Expect(selection.Texts()).To(Equal([]string{"some text", "some other text"}))
+			Expect(driver.ExecuteCall.Body).To(ContainSubstring(`displayed(element) ? element.innerText.trim() : ""`))
+			Expect(driver.ExecuteCall.Arguments).To(Equal([]interface{}{[]types.Element{firstElement, secondElement}, nil}))
+		})
+
+		Context("when the script cannot be run", func() {
+			BeforeEach(func() {
+				driver.ExecuteCall.Err = errors.New("some error")
+				firstElement.GetTextCall.ReturnText = "some text"
+				secondElement.GetTextCall.ReturnText = "some other text"
+			})
+
+			It("reads the text of each element", func() {
+				Expect(selection.Texts()).To(Equal([]string{"some text", "some other text"}))
+			})
+
+			Context("when reading the text of an element fails", func() {
+				It("returns an error", func() {
+					secondElement.GetTextCall.Err = errors.New("some error")
+					_, err := selection.Texts()
+					Expect(err).To(MatchError("failed to retrieve text for 'CSS: li': some error"))
+				})
+			})
+		})
+
+		Context("when no elements are selected", func() {
+			It("returns no texts without running a script", func() {
+				driver.GetElementsCall.ReturnElements = nil
+				Expect(selection.Texts()).To(BeEmpty())
+				Expect(driver.ExecuteCall.Body).To(BeEmpty())
+			})
+		})
+
+		Context("when the elements cannot be retrieved", func() {
+			It("returns an error", func() {
+				driver.GetElementsCall.Err = errors.New("some error")
+				_, err := selection.Texts()
+				Expect(err).To(MatchError("failed to retrieve elements for 'CSS: li': some error"))
+			})
+		})
+	})
+
+	Describe("#Attributes", func() {
+		It("reads the attribute of every element with a single script", func() {
+			driver.ExecuteCall.Result = `["some-value", ""]`
+			Expect(selection.Attributes("href")).To(Equal([]string{"some-value", ""}))
+			Expect(driver.ExecuteCall.Arguments[1]).To(Equal("href"))
+		})
+
+		It("reads the attribute of each element when the script cannot be run", func() {
+			driver.ExecuteCall.Err = errors.New("some error")
+			firstElement.GetAttributeCall.ReturnValue = "some-value"
+			Expect(selection.Attributes("href")).To(Equal([]string{"some-value", ""}))
+			Expect(secondElement.GetAttributeCall.Attribute).To(Equal("href"))
+		})
+	})
+
+	Describe("#CSSValues", func() {
+		It("reads the CSS property of every element with a single script", func() {
+			driver.ExecuteCall.Result = `["block", "none"]`
+			Expect(selection.CSSValues("display")).To(Equal([]string{"block", "none"}))
+			Expect(driver.ExecuteCall.Arguments[1]).To(Equal("display"))
+		})
+
+		It("reads the CSS property of each element when the script cannot be run", func() {
+			driver.ExecuteCall.Err = errors.New("some error")
+			secondElement.GetCSSCall.ReturnValue = "none"
+			Expect(selection.CSSValues("display")).To(Equal([]string{"", "none"}))
+			Expect(firstElement.GetCSSCall.Property).To(Equal("display"))
+		})
+	})
+
+	Describe("#Visibilities", func() {
+		It("reads the visibility of every element with a single script", func() {
+			driver.ExecuteCall.Result = `[true, false]`
+			Expect(selection.Visibilities()).To(Equal([]bool{true, false}))
+		})
+
+		It("reads the visibility of each element when the script cannot be run", func() {
+			driver.ExecuteCall.Err = errors.New("some error")
+			firstElement.IsDisplayedCall.ReturnDisplayed = true
+			Expect(selection.Visibilities()).To(Equal([]bool{true, false}))
+		})
+
+		It("returns an error when reading the visibility of an element fails", func() {
+			driver.ExecuteCall.Err = errors.New("some error")
+			firstElement.IsDisplayedCall.Err = errors.New("some error")
+			_, err := selection.Visibilities()
+			Expect(err).To(MatchError("failed to retrieve visibility for 'CSS: li': some error"))
+		})
+	})
+})
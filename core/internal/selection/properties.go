@@ -2,82 +2,136 @@ package selection
 
 import "fmt"
 
-func (s *Selection) Text() (string, error) {
+func (s *Selection) Text() (text string, err error) {
+	err = s.Wait.retry(func() error {
+		text, err = s.text()
+		return err
+	})
+	return text, err
+}
+
+func (s *Selection) text() (string, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return "", fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	text, err := element.GetText()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve text for '%s': %s", s, err)
+		return "", fmt.Errorf("failed to retrieve text for '%s': %w", s, err)
 	}
 	return text, nil
 }
 
-func (s *Selection) Attribute(attribute string) (string, error) {
+func (s *Selection) Attribute(attribute string) (value string, err error) {
+	err = s.Wait.retry(func() error {
+		value, err = s.attribute(attribute)
+		return err
+	})
+	return value, err
+}
+
+func (s *Selection) attribute(attribute string) (string, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return "", fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	value, err := element.GetAttribute(attribute)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve attribute value for '%s': %s", s, err)
+		return "", fmt.Errorf("failed to retrieve attribute value for '%s': %w", s, err)
 	}
 	return value, nil
 }
 
-func (s *Selection) CSS(property string) (string, error) {
+func (s *Selection) CSS(property string) (value string, err error) {
+	err = s.Wait.retry(func() error {
+		value, err = s.cssValue(property)
+		return err
+	})
+	return value, err
+}
+
+func (s *Selection) cssValue(property string) (string, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return "", fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	value, err := element.GetCSS(property)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve CSS property for '%s': %s", s, err)
+		return "", fmt.Errorf("failed to retrieve CSS property for '%s': %w", s, err)
 	}
 	return value, nil
 }
 
-func (s *Selection) Selected() (bool, error) {
+func (s *Selection) Selected() (selected bool, err error) {
+	err = s.Wait.retry(func() error {
+		selected, err = s.selected()
+		return err
+	})
+	return selected, err
+}
+
+func (s *Selection) selected() (bool, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return false, fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	selected, err := element.IsSelected()
 	if err != nil {
-		return false, fmt.Errorf("failed to determine whether '%s' is selected: %s", s, err)
+		return false, fmt.Errorf("failed to determine whether '%s' is selected: %w", s, err)
 	}
 
 	return selected, nil
 }
 
-func (s *Selection) Visible() (bool, error) {
+func (s *Selection) Visible() (visible bool, err error) {
+	err = s.Wait.retry(func() error {
+		visible, err = s.visible()
+		return err
+	})
+	return visible, err
+}
+
+func (s *Selection) visible() (bool, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return false, fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	visible, err := element.IsDisplayed()
 	if err != nil {
-		return false, fmt.Errorf("failed to determine whether '%s' is visible: %s", s, err)
+		return false, fmt.Errorf("failed to determine whether '%s' is visible: %w", s, err)
 	}
 
 	return visible, nil
 }
 
-func (s *Selection) Enabled() (bool, error) {
+func (s *Selection) Enabled() (enabled bool, err error) {
+	err = s.Wait.retry(func() error {
+		enabled, err = s.enabled()
+		return err
+	})
+	return enabled, err
+}
+
+func (s *Selection) enabled() (bool, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return false, fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	enabled, err := element.IsEnabled()
 	if err != nil {
-		return false, fmt.Errorf("failed to determine whether '%s' is enabled: %s", s, err)
+		return false, fmt.Errorf("failed to determine whether '%s' is enabled: %w", s, err)
 	}
 
 	return enabled, nil
@@ -0,0 +1,206 @@
+package selection
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+const elementRectScript = `var element = arguments[0];
+if (arguments[1]) element.scrollIntoView({block: "center", inline: "center"});
+var rect = element.getBoundingClientRect(), x = rect.left, y = rect.top, view = window;
+try {
+	while (view.frameElement) {
+		var frame = view.frameElement.getBoundingClientRect();
+		x += frame.left + view.frameElement.clientLeft;
+		y += frame.top + view.frameElement.clientTop;
+		view = view.parent;
+	}
+} catch (e) {}
+return {
+	x: x, y: y, width: rect.width, height: rect.height, ratio: window.devicePixelRatio || 1,
+	scrollX: view.pageXOffset, scrollY: view.pageYOffset, viewportHeight: view.innerHeight
+};`
+
+const highlightScript = `for (var i = 0; i < arguments[0].length; i++) {
+	var element = arguments[0][i];
+	element.setAttribute("data-agouti-outline", element.style.outline);
+	element.style.outline = "3px solid #f00";
+}`
+
+const removeHighlightScript = `var elements = document.querySelectorAll("[data-agouti-outline]");
+for (var i = 0; i < elements.length; i++) {
+	elements[i].style.outline = elements[i].getAttribute("data-agouti-outline");
+	elements[i].removeAttribute("data-agouti-outline");
+}`
+
+// elementRect is the position of an element relative to the top-level viewport, in CSS pixels.
+type elementRect struct {
+	X, Y, Width, Height float64
+	Ratio               float64
+	ScrollX, ScrollY    float64
+	ViewportHeight      float64
+}
+
+// bounds returns the area of a screenshot with the provided bounds that is
+// covered by the element. Some WebDrivers, such as PhantomJS, capture the
+// entire page rather than the viewport, so the scroll offset is added when
+// the screenshot is taller than the viewport.
+func (r *elementRect) bounds(screenshot image.Rectangle) image.Rectangle {
+	x, y := r.X, r.Y
+	if float64(screenshot.Dy()) > math.Ceil(r.ViewportHeight*r.Ratio) {
+		x += r.ScrollX
+		y += r.ScrollY
+	}
+
+	scale := func(value float64) int {
+		return int(math.Round(value * r.Ratio))
+	}
+
+	element := image.Rect(scale(x), scale(y), scale(x+r.Width), scale(y+r.Height))
+	return element.Intersect(screenshot)
+}
+
+// ScreenshotImage returns an image of the single element referred to by the
+// selection, cropped from a screenshot of the page after scrolling the element into view.
+func (s *Selection) ScreenshotImage() (elementImage image.Image, err error) {
+	err = s.Wait.retry(func() error {
+		elementImage, err = s.screenshotImage()
+		return err
+	})
+	return elementImage, err
+}
+
+func (s *Selection) screenshotImage() (image.Image, error) {
+	defer s.leaveFrame()
+	element, err := s.getSingleElement()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
+	}
+
+	rect, err := s.elementRect(element, true)
+	if err != nil {
+		return nil, err
+	}
+
+	screenshot, err := s.Driver.GetScreenshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve screenshot: %w", err)
+	}
+
+	pageImage, err := png.Decode(bytes.NewReader(screenshot))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+
+	bounds := rect.bounds(pageImage.Bounds())
+	if bounds.Empty() {
+		return nil, fmt.Errorf("failed to crop screenshot: '%s' is not within the screenshot", s)
+	}
+
+	// images decoded by image/png all support SubImage
+	return pageImage.(subImager).SubImage(bounds), nil
+}
+
+// ScreenshotBounds returns the area covered by the single element referred to by
+// the selection within a page screenshot with the provided bounds, such as the
+// bounds of an image returned by Page.ScreenshotImage or ScreenshotImage.
+// Unlike ScreenshotImage, it does not scroll the element into view.
+// It is not part of the Selection interface, and is used by screenshot matchers.
+func (s *Selection) ScreenshotBounds(screenshot image.Rectangle) (bounds image.Rectangle, err error) {
+	err = s.Wait.retry(func() error {
+		bounds, err = s.screenshotBounds(screenshot)
+		return err
+	})
+	return bounds, err
+}
+
+func (s *Selection) screenshotBounds(screenshot image.Rectangle) (image.Rectangle, error) {
+	defer s.leaveFrame()
+	element, err := s.getSingleElement()
+	if err != nil {
+		return image.Rectangle{}, fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
+	}
+
+	rect, err := s.elementRect(element, false)
+	if err != nil {
+		return image.Rectangle{}, err
+	}
+
+	return rect.bounds(screenshot), nil
+}
+
+func (s *Selection) elementRect(element types.Element, scroll bool) (*elementRect, error) {
+	rect := &elementRect{}
+	if err := s.Driver.Execute(elementRectScript, []interface{}{element, scroll}, rect); err != nil {
+		return nil, fmt.Errorf("failed to retrieve position of '%s': %w", s, err)
+	}
+	if rect.Ratio == 0 {
+		rect.Ratio = 1
+	}
+	return rect, nil
+}
+
+// Screenshot writes a PNG image of the single element referred to by the selection to filename.
+func (s *Selection) Screenshot(filename string) error {
+	elementImage, err := s.ScreenshotImage()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return fmt.Errorf("failed to create directory for screenshot: %w", err)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file for screenshot: %w", err)
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, elementImage); err != nil {
+		return fmt.Errorf("failed to write file for screenshot: %w", err)
+	}
+
+	return nil
+}
+
+// Highlight outlines all of the elements referred to by the selection, so that
+// they stand out in screenshots.
+func (s *Selection) Highlight() error {
+	defer s.leaveFrame()
+	elements, err := s.getElements()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve elements for '%s': %w", s, err)
+	}
+
+	if err := s.Driver.Execute(highlightScript, []interface{}{elements}, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to highlight '%s': %w", s, err)
+	}
+	return nil
+}
+
+// RemoveHighlight removes the outlines added by Highlight from the document
+// that contains the selection.
+func (s *Selection) RemoveHighlight() error {
+	defer s.leaveFrame()
+	if s.frame != nil {
+		if err := s.frame.enterFramePath(); err != nil {
+			return fmt.Errorf("failed to switch to frame '%s': %w", s.frame, err)
+		}
+	}
+
+	if err := s.Driver.Execute(removeHighlightScript, nil, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to remove highlight from '%s': %w", s, err)
+	}
+	return nil
+}
+
+type subImager interface {
+	SubImage(r image.Rectangle) image.Image
+}
@@ -0,0 +1,173 @@
+package selection_test
+
+import (
+	"bytes"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/selection"
+	"github.com/sclevine/agouti/core/internal/types"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Selection screenshots", func() {
+	var (
+		selection *Selection
+		driver    *mocks.Driver
+		element   *mocks.Element
+		red       = color.RGBA{255, 0, 0, 255}
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		element = &mocks.Element{}
+		selection = (&Selection{Driver: driver}).Find("#selector").(*Selection)
+		driver.GetElementsCall.ReturnElements = []types.Element{element}
+
+		pageImage := image.NewRGBA(image.Rect(0, 0, 200, 160))
+		for y := 10; y < 30; y++ {
+			for x := 20; x < 40; x++ {
+				pageImage.Set(x, y, red)
+			}
+		}
+		screenshot := &bytes.Buffer{}
+		png.Encode(screenshot, pageImage)
+		driver.GetScreenshotCall.ReturnImage = screenshot.Bytes()
+		driver.ExecuteCall.Result = `{"x": 10, "y": 5, "width": 10, "height": 10, "ratio": 2, "scrollX": 0, "scrollY": 0, "viewportHeight": 80}`
+	})
+
+	Describe("#ScreenshotImage", func() {
+		It("provides the element to the script that scrolls it into view", func() {
+			selection.ScreenshotImage()
+			Expect(driver.ExecuteCall.Body).To(ContainSubstring("scrollIntoView"))
+			Expect(driver.ExecuteCall.Arguments).To(Equal([]interface{}{element, true}))
+		})
+
+		It("crops the screenshot to the element, scaled by the device pixel ratio", func() {
+			elementImage, err := selection.ScreenshotImage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elementImage.Bounds()).To(Equal(image.Rect(20, 10, 40, 30)))
+			Expect(color.RGBAModel.Convert(elementImage.At(20, 10))).To(Equal(red))
+			Expect(color.RGBAModel.Convert(elementImage.At(39, 29))).To(Equal(red))
+		})
+
+		Context("when the screenshot captures the entire page", func() {
+			It("offsets the element by the scroll position", func() {
+				driver.ExecuteCall.Result = `{"x": 10, "y": 0, "width": 10, "height": 10, "ratio": 2, "scrollX": 0, "scrollY": 5, "viewportHeight": 40}`
+				elementImage, err := selection.ScreenshotImage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(elementImage.Bounds()).To(Equal(image.Rect(20, 10, 40, 30)))
+			})
+		})
+
+		Context("when the element is not within the screenshot", func() {
+			It("returns an error", func() {
+				driver.ExecuteCall.Result = `{"x": 500, "y": 500, "width": 10, "height": 10, "ratio": 1, "viewportHeight": 160}`
+				_, err := selection.ScreenshotImage()
+				Expect(err).To(MatchError("failed to crop screenshot: 'CSS: #selector' is not within the screenshot"))
+			})
+		})
+
+		Context("when the position of the element cannot be retrieved", func() {
+			It("returns an error", func() {
+				driver.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.ScreenshotImage()
+				Expect(err).To(MatchError("failed to retrieve position of 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the driver fails to retrieve a screenshot", func() {
+			It("returns an error", func() {
+				driver.GetScreenshotCall.Err = errors.New("some error")
+				_, err := selection.ScreenshotImage()
+				Expect(err).To(MatchError("failed to retrieve screenshot: some error"))
+			})
+		})
+
+		Context("when the screenshot is not a PNG image", func() {
+			It("returns an error", func() {
+				driver.GetScreenshotCall.ReturnImage = []byte("some-image")
+				_, err := selection.ScreenshotImage()
+				Expect(err.Error()).To(HavePrefix("failed to decode screenshot: "))
+			})
+		})
+	})
+
+	Describe("#ScreenshotBounds", func() {
+		It("returns the area of the screenshot covered by the element without scrolling", func() {
+			Expect(selection.ScreenshotBounds(image.Rect(0, 0, 200, 160))).To(Equal(image.Rect(20, 10, 40, 30)))
+			Expect(driver.ExecuteCall.Arguments).To(Equal([]interface{}{element, false}))
+		})
+
+		It("limits the area to the provided bounds", func() {
+			Expect(selection.ScreenshotBounds(image.Rect(30, 0, 200, 20))).To(Equal(image.Rect(30, 10, 40, 20)))
+		})
+
+		Context("when the position of the element cannot be retrieved", func() {
+			It("returns an error", func() {
+				driver.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.ScreenshotBounds(image.Rect(0, 0, 200, 160))
+				Expect(err).To(MatchError("failed to retrieve position of 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Screenshot", func() {
+		var filename string
+
+		BeforeEach(func() {
+			directory, _ := os.Getwd()
+			filename = filepath.Join(directory, ".test.element.png")
+		})
+
+		AfterEach(func() {
+			os.Remove(filename)
+		})
+
+		It("writes a PNG image of the element to the file", func() {
+			Expect(selection.Screenshot(filename)).To(Succeed())
+			file, _ := os.Open(filename)
+			defer file.Close()
+			elementImage, err := png.Decode(file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elementImage.Bounds().Size()).To(Equal(image.Pt(20, 20)))
+		})
+
+		Context("when the element image cannot be retrieved", func() {
+			It("does not create the file", func() {
+				driver.GetScreenshotCall.Err = errors.New("some error")
+				Expect(selection.Screenshot(filename)).To(MatchError("failed to retrieve screenshot: some error"))
+				_, err := os.Stat(filename)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("#Highlight", func() {
+		It("outlines all of the selected elements", func() {
+			driver.GetElementsCall.ReturnElements = []types.Element{element, element}
+			Expect(selection.Highlight()).To(Succeed())
+			Expect(driver.ExecuteCall.Body).To(ContainSubstring("style.outline"))
+			Expect(driver.ExecuteCall.Arguments).To(Equal([]interface{}{[]types.Element{element, element}}))
+		})
+
+		Context("when the elements cannot be outlined", func() {
+			It("returns an error", func() {
+				driver.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.Highlight()).To(MatchError("failed to highlight 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#RemoveHighlight", func() {
+		It("removes the outlines from the document that contains the selection", func() {
+			Expect(selection.RemoveHighlight()).To(Succeed())
+			Expect(driver.ExecuteCall.Body).To(ContainSubstring(`removeAttribute("data-agouti-outline")`))
+		})
+	})
+})
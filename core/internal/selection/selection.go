@@ -1,6 +1,7 @@
 package selection
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/sclevine/agouti/core/internal/types"
@@ -9,13 +10,48 @@ import (
 
 type Selection struct {
 	Driver    driver
+	Wait      Wait
 	selectors []types.Selector
+	frame     *Selection
 }
 
 type driver interface {
 	GetElements(selector types.Selector) ([]types.Element, error)
+	SetFrame(frame types.Element) error
+	GetScreenshot() ([]byte, error)
+	Execute(body string, arguments []interface{}, result interface{}) error
 	DoubleClick() error
 	MoveTo(element types.Element, point types.Point) error
+	WithContext(ctx context.Context) types.Driver
+}
+
+func (s *Selection) WithContext(ctx context.Context) types.Selection {
+	var frame *Selection
+	if s.frame != nil {
+		frame = s.frame.WithContext(ctx).(*Selection)
+	}
+	return &Selection{s.Driver.WithContext(ctx), s.Wait.WithContext(ctx), s.selectors, frame}
+}
+
+// NewFrame returns a selection within the frame element selected by frame.
+func NewFrame(frame *Selection) *Selection {
+	return &Selection{Driver: frame.Driver, Wait: frame.Wait, frame: frame}
+}
+
+func (s *Selection) Frame(selector string) types.Selection {
+	return NewFrame(s.Find(selector).(*Selection))
+}
+
+// SwitchToFrame switches to the frame element referred to by the selection,
+// entering any frames that contain it first. A frame element selected outside
+// of a frame is located within the current browsing context.
+func (s *Selection) SwitchToFrame() error {
+	return s.Wait.retry(func() error {
+		if err := s.enterFrame(); err != nil {
+			return fmt.Errorf("failed to switch to frame '%s': %w", s, err)
+		}
+		return nil
+	})
 }
 
 func (s *Selection) Find(selector string) types.Selection {
@@ -23,18 +59,25 @@ func (s *Selection) Find(selector string) types.Selection {
 
 	if last == -1 || s.selectors[last].Using != "css selector" {
 		newSelector := types.Selector{Using: "css selector", Value: selector}
-		return &Selection{s.Driver, append(s.selectors, newSelector)}
+		return &Selection{s.Driver, s.Wait, appendSelector(s.selectors, newSelector), s.frame}
 	}
 
 	newSelectorValue := s.selectors[last].Value + " " + selector
 	newSelector := types.Selector{Using: "css selector", Value: newSelectorValue}
-	newSelectors := append(append([]types.Selector(nil), s.selectors[:last]...), newSelector)
-	return &Selection{s.Driver, newSelectors}
+	return &Selection{s.Driver, s.Wait, appendSelector(s.selectors[:last], newSelector), s.frame}
+}
+
+// appendSelector returns a copy of selectors with selector appended, so that
+// selections built from the same selection never share selectors.
+func appendSelector(selectors []types.Selector, selector types.Selector) []types.Selector {
+	newSelectors := make([]types.Selector, len(selectors), len(selectors)+1)
+	copy(newSelectors, selectors)
+	return append(newSelectors, selector)
 }
 
 func (s *Selection) FindXPath(selector string) types.Selection {
 	newSelector := types.Selector{Using: "xpath", Value: selector}
-	return &Selection{s.Driver, append(s.selectors, newSelector)}
+	return &Selection{s.Driver, s.Wait, appendSelector(s.selectors, newSelector), s.frame}
 }
 
 func (s *Selection) FindByLabel(text string) types.Selection {
@@ -42,9 +85,65 @@ func (s *Selection) FindByLabel(text string) types.Selection {
 	return s.FindXPath(selector)
 }
 
+// At returns a selection of the element at index (starting from 0) among the
+// elements referred to by the selection. A negative index counts back from the last element.
+func (s *Selection) At(index int) types.Selection {
+	newSelector := types.Selector{Index: index, Indexed: true}
+	return &Selection{s.Driver, s.Wait, appendSelector(s.selectors, newSelector), s.frame}
+}
+
+// First returns a selection of the first element referred to by the selection.
+func (s *Selection) First() types.Selection {
+	return s.At(0)
+}
+
+// Last returns a selection of the last element referred to by the selection.
+func (s *Selection) Last() types.Selection {
+	return s.At(-1)
+}
+
+// All returns a selection of each element currently referred to by the selection.
+func (s *Selection) All() ([]types.Selection, error) {
+	count, err := s.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	selections := make([]types.Selection, 0, count)
+	for index := 0; index < count; index++ {
+		selections = append(selections, s.At(index))
+	}
+	return selections, nil
+}
+
+// Elements is an alias for All.
+func (s *Selection) Elements() ([]types.Selection, error) {
+	return s.All()
+}
+
+// Each calls iterator with the index and a selection of each element currently
+// referred to by the selection, and stops at the first error returned by iterator.
+func (s *Selection) Each(iterator func(index int, selection types.Selection) error) error {
+	selections, err := s.All()
+	if err != nil {
+		return err
+	}
+
+	for index, selection := range selections {
+		if err := iterator(index, selection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Selection) String() string {
 	var tags []string
 
+	if s.frame != nil {
+		tags = append(tags, "Frame: "+s.frame.String())
+	}
+
 	for _, selector := range s.selectors {
 		tags = append(tags, selector.String())
 	}
@@ -52,17 +151,74 @@ func (s *Selection) String() string {
 	return strings.Join(tags, " | ")
 }
 
+// enterFrame switches to the frame element referred to by the selection.
+// Selections within a frame refer to their frame element by its absolute
+// path from the top-level browsing context, while other selections are made
+// within the current browsing context.
+func (s *Selection) enterFrame() error {
+	if s.frame != nil {
+		if err := s.frame.enterFramePath(); err != nil {
+			return err
+		}
+	}
+
+	frame, err := s.findSingleElement()
+	if err != nil {
+		return err
+	}
+
+	return s.Driver.SetFrame(frame)
+}
+
+// enterFramePath switches to the top-level browsing context, and then to each
+// frame along the path to the frame element referred to by the selection.
+func (s *Selection) enterFramePath() error {
+	if s.frame == nil {
+		if err := s.Driver.SetFrame(nil); err != nil {
+			return err
+		}
+	}
+	return s.enterFrame()
+}
+
+// leaveFrame returns to the top-level browsing context after a selection
+// within a frame has been used.
+func (s *Selection) leaveFrame() {
+	if s.frame != nil {
+		s.Driver.SetFrame(nil)
+	}
+}
+
 func (s *Selection) getElements() ([]types.Element, error) {
+	if s.frame != nil {
+		if err := s.frame.enterFramePath(); err != nil {
+			return nil, fmt.Errorf("failed to switch to frame '%s': %w", s.frame, err)
+		}
+	}
+
+	return s.findElements()
+}
+
+func (s *Selection) findElements() ([]types.Element, error) {
 	if len(s.selectors) == 0 {
 		return nil, errors.New("empty selection")
 	}
 
+	if s.selectors[0].Indexed {
+		return nil, errors.New("index of an empty selection")
+	}
+
 	lastElements, err := s.Driver.GetElements(s.selectors[0])
 	if err != nil {
 		return nil, err
 	}
 
 	for _, selector := range s.selectors[1:] {
+		if selector.Indexed {
+			lastElements = elementAt(lastElements, selector.Index)
+			continue
+		}
+
 		elements := []types.Element{}
 		for _, element := range lastElements {
 			subElements, err := element.GetElements(selector)
@@ -76,35 +232,73 @@ func (s *Selection) getElements() ([]types.Element, error) {
 	return lastElements, nil
 }
 
+func elementAt(elements []types.Element, index int) []types.Element {
+	if index < 0 {
+		index += len(elements)
+	}
+	if index < 0 || index >= len(elements) {
+		return nil
+	}
+	return elements[index : index+1]
+}
+
 func (s *Selection) getSingleElement() (types.Element, error) {
-	elements, err := s.getElements()
+	if s.frame != nil {
+		if err := s.frame.enterFramePath(); err != nil {
+			return nil, fmt.Errorf("failed to switch to frame '%s': %w", s.frame, err)
+		}
+	}
+
+	return s.findSingleElement()
+}
+
+func (s *Selection) findSingleElement() (types.Element, error) {
+	elements, err := s.findElements()
 	if err != nil {
 		return nil, err
 	}
 
 	if len(elements) > 1 {
-		return nil, fmt.Errorf("mutiple elements (%d) were selected", len(elements))
+		return nil, &types.MultipleElementsFoundError{Selector: s.String(), Count: len(elements)}
 	}
 	if len(elements) == 0 {
-		return nil, fmt.Errorf("no element found")
+		return nil, &types.NoElementFoundError{Selector: s.String()}
 	}
 
 	return elements[0], nil
 }
 
-func (s *Selection) Count() (int, error) {
+func (s *Selection) Count() (count int, err error) {
+	err = s.Wait.retry(func() error {
+		count, err = s.count()
+		return err
+	})
+	return count, err
+}
+
+func (s *Selection) count() (int, error) {
+	defer s.leaveFrame()
 	elements, err := s.getElements()
 	if err != nil {
-		return 0, fmt.Errorf("failed to retrieve elements for '%s': %s", s, err)
+		return 0, fmt.Errorf("failed to retrieve elements for '%s': %w", s, err)
 	}
 
 	return len(elements), nil
 }
 
-func (s *Selection) EqualsElement(comparable interface{}) (bool, error) {
+func (s *Selection) EqualsElement(comparable interface{}) (equal bool, err error) {
+	err = s.Wait.retry(func() error {
+		equal, err = s.equalsElement(comparable)
+		return err
+	})
+	return equal, err
+}
+
+func (s *Selection) equalsElement(comparable interface{}) (bool, error) {
+	defer s.leaveFrame()
 	element, err := s.getSingleElement()
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve element with '%s': %s", s, err)
+		return false, fmt.Errorf("failed to retrieve element with '%s': %w", s, err)
 	}
 
 	selection, ok := comparable.(*Selection)
@@ -114,12 +308,12 @@ func (s *Selection) EqualsElement(comparable interface{}) (bool, error) {
 
 	otherElement, err := selection.getSingleElement()
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve element with '%s': %s", comparable, err)
+		return false, fmt.Errorf("failed to retrieve element with '%s': %w", comparable, err)
 	}
 
 	equal, err := element.IsEqualTo(otherElement)
 	if err != nil {
-		return false, fmt.Errorf("failed to compare '%s' to '%s': %s", s, comparable, err)
+		return false, fmt.Errorf("failed to compare '%s' to '%s': %w", s, comparable, err)
 	}
 
 	return equal, nil
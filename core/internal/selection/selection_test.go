@@ -1,7 +1,9 @@
 package selection_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/sclevine/agouti/core/internal/mocks"
@@ -122,6 +124,13 @@ var _ = Describe("Selection", func() {
 			It("returns an error with the number of elements", func() {
 				Expect(selection.Click()).To(MatchError("failed to retrieve element with 'CSS: #selector': mutiple elements (2) were selected"))
 			})
+
+			It("returns an error that can be inspected for the selector and count", func() {
+				var multipleErr *types.MultipleElementsFoundError
+				Expect(errors.As(selection.Click(), &multipleErr)).To(BeTrue())
+				Expect(multipleErr.Selector).To(Equal("CSS: #selector"))
+				Expect(multipleErr.Count).To(Equal(2))
+			})
 		})
 
 		Context("when the driver retrieves zero elements", func() {
@@ -132,6 +141,97 @@ var _ = Describe("Selection", func() {
 			It("fails with an error indicating there were no elements", func() {
 				Expect(selection.Click()).To(MatchError("failed to retrieve element with 'CSS: #selector': no element found"))
 			})
+
+			It("fails with an error that can be inspected for the selector", func() {
+				var notFoundErr *types.NoElementFoundError
+				err := selection.Click()
+				Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+				Expect(notFoundErr.Selector).To(Equal("CSS: #selector"))
+				Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("frames", func() {
+		var (
+			frameElement *mocks.Element
+			frame        types.Selection
+		)
+
+		BeforeEach(func() {
+			frameElement = &mocks.Element{}
+			frame = selection.Frame("iframe")
+			driver.GetElementsCall.ReturnElements = []types.Element{frameElement}
+		})
+
+		Describe("#Frame", func() {
+			It("returns a selection within the selected frame", func() {
+				Expect(frame.Find("input").String()).To(Equal("Frame: CSS: #selector iframe | CSS: input"))
+			})
+
+			It("preserves the frame when scoped to a context", func() {
+				Expect(frame.Find("input").WithContext(context.Background()).String()).To(Equal("Frame: CSS: #selector iframe | CSS: input"))
+			})
+		})
+
+		Describe("#SwitchToFrame", func() {
+			It("switches to the frame element within the current browsing context", func() {
+				Expect(selection.SwitchToFrame()).To(Succeed())
+				Expect(driver.SetFrameCall.Frames).To(Equal([]types.Element{frameElement}))
+			})
+
+			It("switches to nested frames when called successively", func() {
+				nestedElement := &mocks.Element{}
+				Expect(selection.SwitchToFrame()).To(Succeed())
+				driver.GetElementsCall.ReturnElements = []types.Element{nestedElement}
+				Expect(selection.Find("iframe").SwitchToFrame()).To(Succeed())
+				Expect(driver.SetFrameCall.Frames).To(Equal([]types.Element{frameElement, nestedElement}))
+			})
+
+			It("enters the frames containing the frame element from the top-level browsing context first", func() {
+				Expect(frame.Find("iframe").SwitchToFrame()).To(Succeed())
+				Expect(driver.SetFrameCall.Frames).To(Equal([]types.Element{nil, frameElement, frameElement}))
+			})
+
+			Context("when switching frames fails", func() {
+				It("returns an error", func() {
+					driver.SetFrameCall.Err = errors.New("some error")
+					Expect(selection.SwitchToFrame()).To(MatchError("failed to switch to frame 'CSS: #selector': some error"))
+				})
+			})
+		})
+
+		Context("when a selection within a frame is used", func() {
+			BeforeEach(func() {
+				Expect(frame.Find("input").Click()).To(Succeed())
+			})
+
+			It("switches to the frame from the top-level browsing context before retrieving the element", func() {
+				Expect(driver.SetFrameCall.Frames[:2]).To(Equal([]types.Element{nil, frameElement}))
+				Expect(frameElement.ClickCall.Called).To(BeTrue())
+			})
+
+			It("returns to the top-level browsing context afterwards", func() {
+				Expect(driver.SetFrameCall.Frames).To(HaveLen(3))
+				Expect(driver.SetFrameCall.Frame).To(BeNil())
+			})
+		})
+
+		Context("when switching to the frame fails", func() {
+			It("returns an error", func() {
+				driver.SetFrameCall.Err = errors.New("some error")
+				err := frame.Find("input").Click()
+				Expect(err).To(MatchError("failed to retrieve element with 'Frame: CSS: #selector iframe | CSS: input': failed to switch to frame 'CSS: #selector iframe': some error"))
+			})
+		})
+	})
+
+	Describe("#WithContext", func() {
+		It("returns a selection with the same selectors and a driver scoped to the provided context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			Expect(selection.WithContext(ctx).String()).To(Equal("CSS: #selector"))
+			Expect(driver.WithContextCall.Ctx).To(Equal(ctx))
 		})
 	})
 
@@ -169,6 +269,151 @@ var _ = Describe("Selection", func() {
 		})
 	})
 
+	Describe("#At", func() {
+		var elements []*mocks.Element
+
+		BeforeEach(func() {
+			elements = []*mocks.Element{{}, {}, {}}
+			driver.GetElementsCall.ReturnElements = []types.Element{elements[0], elements[1], elements[2]}
+		})
+
+		It("adds the index to the selection", func() {
+			Expect(selection.At(2).String()).To(Equal("CSS: #selector | [2]"))
+		})
+
+		It("refers to the element at the index", func() {
+			Expect(selection.At(1).Click()).To(Succeed())
+			Expect(elements[1].ClickCall.Called).To(BeTrue())
+			Expect(elements[0].ClickCall.Called).To(BeFalse())
+		})
+
+		It("counts negative indices back from the last element", func() {
+			Expect(selection.At(-2).Click()).To(Succeed())
+			Expect(elements[1].ClickCall.Called).To(BeTrue())
+		})
+
+		It("finds elements within the element at the index", func() {
+			child := &mocks.Element{}
+			elements[2].GetElementsCall.ReturnElements = []types.Element{child}
+			subselection := selection.At(2).Find("td")
+			Expect(subselection.String()).To(Equal("CSS: #selector | [2] | CSS: td"))
+			Expect(subselection.Click()).To(Succeed())
+			Expect(elements[2].GetElementsCall.Selector).To(Equal(types.Selector{Using: "css selector", Value: "td"}))
+			Expect(child.ClickCall.Called).To(BeTrue())
+		})
+
+		It("does not change the original selection", func() {
+			selection.At(1)
+			Expect(selection.String()).To(Equal("CSS: #selector"))
+		})
+
+		It("does not share selectors between selections within the element at the index", func() {
+			row := selection.Find("table").FindXPath("tr").At(1)
+			first := row.Find(".a")
+			second := row.Find(".b")
+			Expect(first.String()).To(Equal("CSS: #selector table | XPath: tr | [1] | CSS: .a"))
+			Expect(second.String()).To(Equal("CSS: #selector table | XPath: tr | [1] | CSS: .b"))
+		})
+
+		Context("when there is no element at the index", func() {
+			It("returns an error", func() {
+				err := selection.At(3).Click()
+				Expect(err).To(MatchError("failed to retrieve element with 'CSS: #selector | [3]': no element found"))
+				Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+			})
+		})
+
+		Context("when the selection has no selectors", func() {
+			It("returns an error without retrieving any elements", func() {
+				driver.GetElementsCall.ReturnElements = []types.Element{elements[0]}
+				err := selection.Frame("iframe").At(0).Click()
+				Expect(err).To(MatchError("failed to retrieve element with 'Frame: CSS: #selector iframe | [0]': index of an empty selection"))
+				Expect(driver.GetElementsCall.Selector).To(Equal(types.Selector{Using: "css selector", Value: "#selector iframe"}))
+			})
+		})
+	})
+
+	Describe("#First", func() {
+		It("refers to the first element", func() {
+			Expect(selection.First().String()).To(Equal("CSS: #selector | [0]"))
+		})
+	})
+
+	Describe("#Last", func() {
+		It("refers to the last element", func() {
+			other := &mocks.Element{}
+			driver.GetElementsCall.ReturnElements = []types.Element{element, other}
+			Expect(selection.Last().String()).To(Equal("CSS: #selector | [-1]"))
+			Expect(selection.Last().Click()).To(Succeed())
+			Expect(other.ClickCall.Called).To(BeTrue())
+		})
+	})
+
+	Describe("#All", func() {
+		It("returns a selection of each element", func() {
+			driver.GetElementsCall.ReturnElements = []types.Element{element, element}
+			selections, err := selection.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selections).To(HaveLen(2))
+			Expect(selections[0].String()).To(Equal("CSS: #selector | [0]"))
+			Expect(selections[1].String()).To(Equal("CSS: #selector | [1]"))
+		})
+
+		Context("when the elements cannot be retrieved", func() {
+			It("returns an error", func() {
+				driver.GetElementsCall.Err = errors.New("some error")
+				_, err := selection.All()
+				Expect(err).To(MatchError("failed to retrieve elements for 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Elements", func() {
+		It("returns a selection of each element", func() {
+			driver.GetElementsCall.ReturnElements = []types.Element{element, element}
+			selections, err := selection.Elements()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selections).To(HaveLen(2))
+			Expect(selections[1].String()).To(Equal("CSS: #selector | [1]"))
+		})
+	})
+
+	Describe("#Each", func() {
+		BeforeEach(func() {
+			driver.GetElementsCall.ReturnElements = []types.Element{element, element, element}
+		})
+
+		It("calls the iterator with the index and selection of each element", func() {
+			var visited []string
+			err := selection.Each(func(index int, selection types.Selection) error {
+				visited = append(visited, fmt.Sprintf("%d: %s", index, selection))
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(visited).To(Equal([]string{"0: CSS: #selector | [0]", "1: CSS: #selector | [1]", "2: CSS: #selector | [2]"}))
+		})
+
+		It("stops at the first error returned by the iterator", func() {
+			calls := 0
+			err := selection.Each(func(index int, _ types.Selection) error {
+				calls++
+				if index == 1 {
+					return errors.New("some error")
+				}
+				return nil
+			})
+			Expect(err).To(MatchError("some error"))
+			Expect(calls).To(Equal(2))
+		})
+
+		Context("when the elements cannot be retrieved", func() {
+			It("returns an error", func() {
+				driver.GetElementsCall.Err = errors.New("some error")
+				Expect(selection.Each(nil)).To(MatchError("failed to retrieve elements for 'CSS: #selector': some error"))
+			})
+		})
+	})
+
 	Describe("#String", func() {
 		It("returns the separated selectors", func() {
 			Expect(selection.FindXPath("//subselector").String()).To(Equal("CSS: #selector | XPath: //subselector"))
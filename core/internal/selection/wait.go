@@ -0,0 +1,94 @@
+package selection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+	"time"
+)
+
+// DefaultPollInterval is how often a selection with a timeout retries a failed
+// operation when no poll interval is set.
+var DefaultPollInterval = 100 * time.Millisecond
+
+// Wait is how long a selection retries an operation that failed because the
+// page has not caught up yet, such as when the selected element does not exist yet.
+// A zero Timeout disables retrying.
+type Wait struct {
+	Timeout  time.Duration
+	Interval time.Duration
+	ctx      context.Context
+}
+
+// WithContext returns a wait that stops retrying once ctx is done.
+func (w Wait) WithContext(ctx context.Context) Wait {
+	w.ctx = ctx
+	return w
+}
+
+// WithTimeout returns a selection that retries finding, acting on and reading
+// the selected elements for up to timeout while they are missing, ambiguous,
+// stale or not interactable.
+func (s *Selection) WithTimeout(timeout time.Duration) types.Selection {
+	selection := *s
+	selection.Wait.Timeout = timeout
+	return &selection
+}
+
+// WithPollInterval returns a selection that retries at the provided interval
+// when it has a timeout.
+func (s *Selection) WithPollInterval(interval time.Duration) types.Selection {
+	selection := *s
+	selection.Wait.Interval = interval
+	return &selection
+}
+
+func (w Wait) retry(operation func() error) error {
+	if w.Timeout <= 0 {
+		return operation()
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ctx := w.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
+		err := operation()
+		if err == nil || !retryable(err) {
+			return err
+		}
+
+		waited := time.Since(start)
+		remaining := w.Timeout - waited
+		if remaining <= 0 {
+			return fmt.Errorf("%w (waited %s over %d attempts)", err, waited.Round(time.Millisecond), attempts)
+		}
+
+		delay := interval
+		if remaining < interval {
+			delay = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (stopped waiting after %d attempts: %w)", err, attempts, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+func retryable(err error) bool {
+	var multipleErr *types.MultipleElementsFoundError
+	return errors.Is(err, types.ErrNoSuchElement) ||
+		errors.Is(err, types.ErrStaleElement) ||
+		errors.Is(err, types.ErrElementNotVisible) ||
+		errors.As(err, &multipleErr)
+}
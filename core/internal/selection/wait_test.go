@@ -0,0 +1,115 @@
+package selection_test
+
+import (
+	"context"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	. "github.com/sclevine/agouti/core/internal/selection"
+	"github.com/sclevine/agouti/core/internal/types"
+	"time"
+)
+
+type delayedDriver struct {
+	*mocks.Driver
+	missingCalls int
+}
+
+func (d *delayedDriver) GetElements(selector types.Selector) ([]types.Element, error) {
+	elements, err := d.Driver.GetElements(selector)
+	if d.GetElementsCall.Count <= d.missingCalls {
+		return nil, err
+	}
+	return elements, err
+}
+
+var _ = Describe("Wait", func() {
+	var (
+		selection types.Selection
+		driver    *mocks.Driver
+		element   *mocks.Element
+	)
+
+	BeforeEach(func() {
+		driver = &mocks.Driver{}
+		element = &mocks.Element{}
+		driver.GetElementsCall.ReturnElements = []types.Element{element}
+		selection = (&Selection{Driver: &delayedDriver{driver, 2}}).Find("#selector")
+	})
+
+	Context("without a timeout", func() {
+		It("fails after a single attempt", func() {
+			err := selection.Click()
+			Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+			Expect(driver.GetElementsCall.Count).To(Equal(1))
+		})
+	})
+
+	Context("with a timeout", func() {
+		BeforeEach(func() {
+			selection = selection.WithTimeout(time.Second).WithPollInterval(time.Millisecond)
+		})
+
+		It("retries until the element is found", func() {
+			Expect(selection.Click()).To(Succeed())
+			Expect(driver.GetElementsCall.Count).To(Equal(3))
+			Expect(element.ClickCall.Called).To(BeTrue())
+		})
+
+		It("retries property reads", func() {
+			element.GetTextCall.ReturnText = "some text"
+			Expect(selection.Text()).To(Equal("some text"))
+			Expect(driver.GetElementsCall.Count).To(Equal(3))
+		})
+
+		It("keeps the timeout for selections within the selection", func() {
+			Expect(selection.Find("#child").(*Selection).Wait).To(Equal(Wait{Timeout: time.Second, Interval: time.Millisecond}))
+		})
+
+		It("retries when multiple elements are found", func() {
+			driver.GetElementsCall.ReturnElements = []types.Element{element, element}
+			selection = selection.WithTimeout(20 * time.Millisecond)
+			err := selection.Click()
+			var multipleErr *types.MultipleElementsFoundError
+			Expect(errors.As(err, &multipleErr)).To(BeTrue())
+			Expect(driver.GetElementsCall.Count).To(BeNumerically(">", 3))
+		})
+
+		It("retries actions on stale and not interactable elements", func() {
+			element.ClickCall.Err = &types.WebDriverError{Code: "stale element reference"}
+			selection = selection.WithTimeout(20 * time.Millisecond)
+			Expect(errors.Is(selection.Click(), types.ErrStaleElement)).To(BeTrue())
+			Expect(driver.GetElementsCall.Count).To(BeNumerically(">", 3))
+		})
+
+		It("does not retry other errors", func() {
+			driver.GetElementsCall.Err = errors.New("some error")
+			Expect(selection.Click()).To(MatchError("failed to retrieve element with 'CSS: #selector': some error"))
+			Expect(driver.GetElementsCall.Count).To(Equal(1))
+		})
+
+		Context("when the deadline passes", func() {
+			It("returns the last error with how long it waited and how many attempts it made", func() {
+				driver.GetElementsCall.ReturnElements = nil
+				selection = selection.WithTimeout(20 * time.Millisecond).WithPollInterval(5 * time.Millisecond)
+				err := selection.Click()
+				Expect(err).To(MatchError(MatchRegexp(`^failed to retrieve element with 'CSS: #selector': no element found \(waited \d+ms over \d+ attempts\)$`)))
+				Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+				Expect(driver.GetElementsCall.Count).To(BeNumerically(">=", 3))
+			})
+		})
+
+		Context("when the context is done", func() {
+			It("stops retrying without waiting for the deadline", func() {
+				driver.GetElementsCall.ReturnElements = nil
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				err := selection.WithTimeout(time.Hour).WithContext(ctx).Click()
+				Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+				Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+				Expect(driver.GetElementsCall.Count).To(Equal(1))
+			})
+		})
+	})
+})
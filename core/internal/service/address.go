@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Ports is an inclusive range of local ports that a WebDriver may listen on.
+// The zero value lets the operating system choose any free port.
+type Ports struct {
+	Min int
+	Max int
+}
+
+// freeAddress returns a local address that nothing was listening on when it
+// was checked, starting from a random port in the range so that concurrent
+// callers are unlikely to be handed the same one.
+func (p Ports) freeAddress() (string, error) {
+	if p.Min == 0 && p.Max == 0 {
+		return listenAddress("127.0.0.1:0")
+	}
+
+	if p.Min <= 0 || p.Max < p.Min {
+		return "", fmt.Errorf("invalid port range %d-%d", p.Min, p.Max)
+	}
+
+	size := p.Max - p.Min + 1
+	offset := rand.Intn(size)
+	for i := 0; i < size; i++ {
+		port := p.Min + (offset+i)%size
+		if address, err := listenAddress("127.0.0.1:" + strconv.Itoa(port)); err == nil {
+			return address, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free port in range %d-%d", p.Min, p.Max)
+}
+
+func listenAddress(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	return listener.Addr().String(), nil
+}
+
+// expandAddress replaces the {{address}}, {{host}} and {{port}} placeholders in value.
+func expandAddress(value, address string) string {
+	host, port, _ := net.SplitHostPort(address)
+	return strings.NewReplacer("{{address}}", address, "{{host}}", host, "{{port}}", port).Replace(value)
+}
+
+var addressInUseMessages = []string{"address already in use", "eaddrinuse", "bindexception", "is busy"}
+
+func addressInUse(output string) bool {
+	output = strings.ToLower(output)
+	for _, message := range addressInUseMessages {
+		if strings.Contains(output, message) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"github.com/sclevine/agouti/core/internal/types"
+	"strings"
+)
+
+var w3cNames = map[string]string{
+	"version":                  "browserVersion",
+	"platform":                 "platformName",
+	"acceptSslCerts":           "acceptInsecureCerts",
+	"unexpectedAlertBehaviour": "unhandledPromptBehavior",
+	"chromeOptions":            "goog:chromeOptions",
+	"loggingPrefs":             "goog:loggingPrefs",
+}
+
+var w3cStandard = map[string]bool{
+	"browserName":               true,
+	"browserVersion":            true,
+	"platformName":              true,
+	"acceptInsecureCerts":       true,
+	"pageLoadStrategy":          true,
+	"proxy":                     true,
+	"setWindowRect":             true,
+	"timeouts":                  true,
+	"strictFileInteractability": true,
+	"unhandledPromptBehavior":   true,
+}
+
+// newSessionRequest offers the capabilities in both the JSON Wire and W3C
+// formats so that the WebDriver may respond in whichever dialect it speaks.
+// Required capabilities take precedence over desired capabilities with the same key.
+func newSessionRequest(capabilities *types.Capabilities) interface{} {
+	if capabilities == nil {
+		capabilities = types.NewCapabilities()
+	}
+
+	optional := map[string]interface{}{}
+	for key, value := range capabilities.Desired {
+		if _, ok := capabilities.Required[key]; !ok {
+			optional[key] = value
+		}
+	}
+
+	desired := map[string]interface{}{}
+	for key, value := range optional {
+		desired[key] = value
+	}
+	for key, value := range capabilities.Required {
+		desired[key] = value
+	}
+
+	firstMatch := []interface{}{map[string]interface{}{}}
+	if w3cDesired := w3cCapabilities(optional); len(w3cDesired) > 0 {
+		firstMatch = append([]interface{}{w3cDesired}, firstMatch...)
+	}
+
+	request := map[string]interface{}{
+		"desiredCapabilities": desired,
+		"capabilities": map[string]interface{}{
+			"alwaysMatch": w3cCapabilities(capabilities.Required),
+			"firstMatch":  firstMatch,
+		},
+	}
+	if len(capabilities.Required) > 0 {
+		request["requiredCapabilities"] = capabilities.Required
+	}
+	return request
+}
+
+// w3cCapabilities renames JSON Wire capabilities to their W3C equivalents and
+// omits capabilities that a W3C WebDriver would reject.
+func w3cCapabilities(capabilities map[string]interface{}) map[string]interface{} {
+	converted := map[string]interface{}{}
+	for key, value := range capabilities {
+		if name, ok := w3cNames[key]; ok {
+			key = name
+		}
+
+		if !w3cStandard[key] && !strings.Contains(key, ":") {
+			continue
+		}
+
+		switch typed := value.(type) {
+		case string:
+			if key == "platformName" {
+				if strings.EqualFold(typed, "ANY") {
+					continue
+				}
+				value = strings.ToLower(typed)
+			}
+		case types.Proxy:
+			typed.ProxyType = strings.ToLower(typed.ProxyType)
+			value = typed
+		}
+
+		converted[key] = value
+	}
+	return converted
+}
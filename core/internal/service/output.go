@@ -0,0 +1,40 @@
+package service
+
+import (
+	"strings"
+	"sync"
+)
+
+const outputTailSize = 4096
+
+// tailBuffer retains the most recent output written by a WebDriver process.
+type tailBuffer struct {
+	mutex     sync.Mutex
+	data      []byte
+	truncated bool
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.data = append(t.data, p...)
+	if overflow := len(t.data) - outputTailSize; overflow > 0 {
+		t.data = append([]byte(nil), t.data[overflow:]...)
+		t.truncated = true
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tail := string(t.data)
+	if t.truncated {
+		if newline := strings.Index(tail, "\n"); newline != -1 {
+			tail = tail[newline+1:]
+		}
+	}
+	return strings.TrimSpace(tail)
+}
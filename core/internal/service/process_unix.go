@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package service
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// startProcessGroup places the WebDriver in its own process group so that
+// any browsers it launches can be signalled along with it.
+func startProcessGroup(command *exec.Cmd) {
+	command.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func interruptProcess(process *os.Process) error {
+	return syscall.Kill(-process.Pid, syscall.SIGINT)
+}
+
+func killProcess(process *os.Process) error {
+	return syscall.Kill(-process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+)
+
+func startProcessGroup(command *exec.Cmd) {}
+
+func interruptProcess(process *os.Process) error {
+	return process.Signal(os.Interrupt)
+}
+
+func killProcess(process *os.Process) error {
+	return process.Kill()
+}
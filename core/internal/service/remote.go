@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
+	"net/http"
+	"time"
+)
+
+// Remote is a WebDriver service that is managed outside of Agouti, such as a
+// Selenium grid or a ChromeDriver that is already running.
+type Remote struct {
+	URL          string
+	Timeout      time.Duration
+	PollInterval time.Duration
+	Client       *http.Client
+	running      bool
+}
+
+func (r *Remote) StartContext(ctx context.Context) error {
+	if r.running {
+		return fmt.Errorf("%s is already connected", r.URL)
+	}
+
+	if err := waitForStatus(ctx, r.client(), r.URL, r.Timeout, r.PollInterval, nil); err != nil {
+		return fmt.Errorf("failed to reach webdriver at %s: %w", r.URL, err)
+	}
+
+	r.running = true
+	return nil
+}
+
+func (r *Remote) WebDriverURL() string {
+	return r.URL
+}
+
+func (r *Remote) Stop() {
+	r.running = false
+}
+
+func (r *Remote) CreateSessionContext(ctx context.Context, capabilities *types.Capabilities) (*session.Session, error) {
+	if !r.running {
+		return nil, fmt.Errorf("%s not connected", r.URL)
+	}
+
+	return createSession(ctx, r.client(), r.URL, r.URL, capabilities)
+}
+
+func (r *Remote) client() *http.Client {
+	if r.Client == nil {
+		return &http.Client{}
+	}
+	return r.Client
+}
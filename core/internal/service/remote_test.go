@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/core/internal/service"
+	"github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("Remote", func() {
+	var (
+		remote        *Remote
+		server        *httptest.Server
+		requestPaths  []string
+		authorization string
+		notReady      int
+	)
+
+	BeforeEach(func() {
+		requestPaths = nil
+		notReady = 0
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			requestPaths = append(requestPaths, request.URL.Path)
+			authorization = request.Header.Get("Authorization")
+			if request.URL.Path == "/wd/hub/session" {
+				response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {"browserName": "chrome"}}}`))
+				return
+			}
+			if notReady > 0 {
+				notReady--
+				response.Write([]byte(`{"value": {"ready": false, "message": "Session already started"}}`))
+				return
+			}
+			response.Write([]byte(`{"value": {"ready": true}}`))
+		}))
+		remote = &Remote{URL: server.URL + "/wd/hub", Timeout: time.Second}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#StartContext", func() {
+		It("checks the status of the remote WebDriver", func() {
+			Expect(remote.StartContext(context.Background())).To(Succeed())
+			Expect(requestPaths).To(Equal([]string{"/wd/hub/status"}))
+		})
+
+		It("uses the provided HTTP client", func() {
+			remote.Client = &http.Client{Transport: basicAuthTransport{}}
+			remote.StartContext(context.Background())
+			Expect(authorization).To(HavePrefix("Basic "))
+		})
+
+		Context("when the remote WebDriver is not ready", func() {
+			It("waits until it is ready", func() {
+				notReady = 2
+				remote.PollInterval = time.Millisecond
+				Expect(remote.StartContext(context.Background())).To(Succeed())
+				Expect(requestPaths).To(HaveLen(3))
+			})
+
+			It("returns an error with the status message when it does not become ready", func() {
+				notReady = 1000
+				remote.Timeout = 50 * time.Millisecond
+				remote.PollInterval = time.Second
+				err := remote.StartContext(context.Background())
+				Expect(err).To(MatchError("failed to reach webdriver at " + server.URL + "/wd/hub: webdriver is not ready: Session already started"))
+			})
+		})
+
+		Context("when the status of the remote WebDriver does not report readiness", func() {
+			It("is ready", func() {
+				server.Config.Handler = http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					response.Write([]byte(`{"status": 0, "value": {"build": {"version": "1.9.8"}}}`))
+				})
+				Expect(remote.StartContext(context.Background())).To(Succeed())
+			})
+		})
+
+		Context("when the remote WebDriver cannot be reached", func() {
+			It("returns an error", func() {
+				server.Close()
+				remote.Timeout = 0
+				err := remote.StartContext(context.Background())
+				Expect(err.Error()).To(HavePrefix("failed to reach webdriver at " + server.URL + "/wd/hub"))
+			})
+		})
+
+		Context("when already connected", func() {
+			It("returns an error", func() {
+				remote.StartContext(context.Background())
+				Expect(remote.StartContext(context.Background())).To(MatchError(server.URL + "/wd/hub is already connected"))
+			})
+		})
+	})
+
+	Describe("#CreateSessionContext", func() {
+		Context("when connected", func() {
+			It("returns a session on the remote WebDriver", func() {
+				remote.StartContext(context.Background())
+				newSession, err := remote.CreateSessionContext(context.Background(), types.NewCapabilities())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newSession.URL).To(Equal(server.URL + "/wd/hub/session/some-id"))
+				Expect(newSession.Dialect).To(Equal(session.W3C))
+				Expect(newSession.NegotiatedCapabilities()).To(Equal(map[string]interface{}{"browserName": "chrome"}))
+			})
+		})
+
+		Context("when not connected", func() {
+			It("returns an error", func() {
+				remote.StartContext(context.Background())
+				remote.Stop()
+				_, err := remote.CreateSessionContext(context.Background(), types.NewCapabilities())
+				Expect(err).To(MatchError(server.URL + "/wd/hub not connected"))
+			})
+		})
+	})
+})
+
+type basicAuthTransport struct{}
+
+func (basicAuthTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request.SetBasicAuth("some-user", "some-password")
+	return http.DefaultTransport.RoundTrip(request)
+}
@@ -1,29 +1,76 @@
 package service
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
 	"strings"
-	"syscall"
 	"time"
 )
 
+// startAttempts is the number of ports tried when a WebDriver fails to start
+// because its address is already in use.
+const startAttempts = 3
+
+// DefaultPollInterval is how often a starting WebDriver is checked when no PollInterval is provided.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// DefaultGracePeriod is how long Stop waits for an interrupted WebDriver to exit
+// before killing it when no GracePeriod is provided.
+const DefaultGracePeriod = 5 * time.Second
+
+// outputWaitDelay is how long the output of an exited WebDriver is copied
+// before it is closed. Browsers launched by the WebDriver inherit its output,
+// so without a limit, a crashed WebDriver is not noticed while they keep running.
+const outputWaitDelay = time.Second
+
+// Service is a WebDriver process managed by Agouti. The URL and Command may
+// contain {{address}}, {{host}} and {{port}} placeholders, which are replaced
+// with a free local address each time the process is started.
 type Service struct {
-	URL     string
-	Timeout time.Duration
-	Command []string
-	process *os.Process
-}
+	URL        string
+	Timeout    time.Duration
+	Command    []string
+	Client     *http.Client
+	Output     io.Writer
+	OutputPath string
+
+	// Ports restricts the ports used to replace the address placeholders.
+	Ports Ports
+
+	// Env holds additional "KEY=value" environment variables for the process.
+	Env []string
 
-type Capabilities struct {
-	BrowserName string `json:"browserName,omitempty"`
-	Version     string `json:"version,omitempty"`
-	Platform    string `json:"platform,omitempty"`
+	// Dir is the working directory of the process. It defaults to the current directory.
+	Dir string
+
+	// PollInterval is how often the WebDriver is checked while it starts.
+	PollInterval time.Duration
+
+	// GracePeriod is how long Stop waits for the WebDriver to exit after
+	// interrupting it before killing its entire process group.
+	GracePeriod time.Duration
+
+	// MaxRestarts is the number of times a WebDriver that exited unexpectedly
+	// is restarted when a new session is requested. Restarts are disabled by default.
+	MaxRestarts int
+
+	process  *os.Process
+	exited   chan struct{}
+	exitErr  error
+	stopping bool
+	restarts int
+	address  string
+	output   *tailBuffer
 }
 
 func (s *Service) name() string {
@@ -31,88 +78,319 @@ func (s *Service) name() string {
 }
 
 func (s *Service) Start() error {
+	return s.StartContext(context.Background())
+}
+
+func (s *Service) StartContext(ctx context.Context) error {
 	if s.process != nil {
 		return fmt.Errorf("%s is already running", s.name())
 	}
 
-	command := exec.Command(s.name(), s.Command[1:]...)
+	for attempt := 1; ; attempt++ {
+		err := s.start(ctx)
+		if err == nil || attempt == startAttempts || !s.templated() || !addressInUse(s.output.String()) {
+			return err
+		}
+	}
+}
+
+// WebDriverURL returns the URL of the WebDriver, including the address
+// it was started on.
+func (s *Service) WebDriverURL() string {
+	return expandAddress(s.URL, s.address)
+}
+
+func (s *Service) templated() bool {
+	return strings.Contains(s.URL+strings.Join(s.Command, " "), "{{")
+}
+
+func (s *Service) start(ctx context.Context) error {
+	s.output = &tailBuffer{}
+	if s.templated() {
+		address, err := s.Ports.freeAddress()
+		if err != nil {
+			return fmt.Errorf("failed to locate a free port for %s: %s", s.name(), err)
+		}
+		s.address = address
+	}
+
+	arguments := make([]string, len(s.Command)-1)
+	for i, argument := range s.Command[1:] {
+		arguments[i] = expandAddress(argument, s.address)
+	}
+
+	command := exec.Command(s.name(), arguments...)
+	command.Dir = s.Dir
+	if len(s.Env) > 0 {
+		command.Env = append(os.Environ(), s.Env...)
+	}
+	startProcessGroup(command)
+
+	output, closeOutput, err := s.outputWriter()
+	if err != nil {
+		return fmt.Errorf("unable to open output file for %s: %s", s.name(), err)
+	}
+	command.Stdout = output
+	command.Stderr = output
+	command.WaitDelay = outputWaitDelay
 
 	if err := command.Start(); err != nil {
+		closeOutput()
 		return fmt.Errorf("unable to run %s: %s", s.name(), err)
 	}
 
 	s.process = command.Process
+	s.stopping = false
+	s.exited = make(chan struct{})
+	go func(exited chan struct{}) {
+		s.exitErr = command.Wait()
+		closeOutput()
+		close(exited)
+	}(s.exited)
 
-	return s.waitForServer()
+	return s.waitForServer(ctx)
 }
 
-func (s *Service) waitForServer() error {
-	client := &http.Client{}
-	request, _ := http.NewRequest("GET", fmt.Sprintf("%s/status", s.URL), nil)
+func (s *Service) outputWriter() (io.Writer, func(), error) {
+	writers := []io.Writer{s.output}
+	closeOutput := func() {}
 
-	timeoutChan := time.After(s.Timeout)
-	failedChan := make(chan struct{}, 1)
-	startedChan := make(chan struct{})
+	if s.Output != nil {
+		writers = append(writers, s.Output)
+	}
 
-	go func() {
-		_, err := client.Do(request)
-		for err != nil {
-			select {
-			case <-failedChan:
-				return
-			default:
-				time.Sleep(500 * time.Millisecond)
-				_, err = client.Do(request)
-			}
+	if s.OutputPath != "" {
+		file, err := os.OpenFile(s.OutputPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, err
 		}
-		startedChan <- struct{}{}
-	}()
+		writers = append(writers, file)
+		closeOutput = func() { file.Close() }
+	}
 
-	select {
-	case <-timeoutChan:
-		failedChan <- struct{}{}
+	return io.MultiWriter(writers...), closeOutput, nil
+}
+
+func (s *Service) waitForServer(ctx context.Context) error {
+	if err := waitForStatus(ctx, s.client(), s.WebDriverURL(), s.Timeout, s.PollInterval, s.exited); err != nil {
+		startErr := s.startupError()
 		s.Stop()
-		return fmt.Errorf("%s webdriver failed to start", s.name())
-	case <-startedChan:
+		return startErr
+	}
+	return nil
+}
+
+// startupError describes why the WebDriver failed to start, including its
+// exit status and the end of its output when they are available.
+func (s *Service) startupError() error {
+	message := fmt.Sprintf("%s webdriver failed to start", s.name())
+
+	select {
+	case <-s.exited:
+		if s.exitErr != nil {
+			message += ": " + s.exitErr.Error()
+		} else {
+			message += ": exited unexpectedly"
+		}
+	default:
+	}
+
+	if tail := s.output.String(); tail != "" {
+		message += "\noutput:\n" + tail
+	}
+
+	return errors.New(message)
+}
+
+func waitForStatus(ctx context.Context, client *http.Client, url string, timeout, interval time.Duration, exited <-chan struct{}) error {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		request, _ := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/status", url), nil)
+		response, err := client.Do(request)
+		if err == nil {
+			err = statusReady(response)
+			response.Body.Close()
+			if err == nil {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-exited:
+			return err
+		case <-time.After(interval):
+		}
+	}
+}
+
+// statusReady returns an error if a W3C WebDriver reports that it is not ready
+// to create a session. JSON Wire WebDrivers do not report readiness, so any
+// response from them is ready.
+func statusReady(response *http.Response) error {
+	var status struct {
+		Value struct {
+			Ready   *bool  `json:"ready"`
+			Message string `json:"message"`
+		} `json:"value"`
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(&status); err != nil || status.Value.Ready == nil || *status.Value.Ready {
 		return nil
 	}
+
+	if status.Value.Message == "" {
+		return errors.New("webdriver is not ready")
+	}
+	return fmt.Errorf("webdriver is not ready: %s", status.Value.Message)
 }
 
+// Stop interrupts the WebDriver and waits for it to exit, killing its process
+// group if it is still running after the grace period. It does nothing if the
+// WebDriver was never started.
 func (s *Service) Stop() {
-	s.process.Signal(syscall.SIGINT)
-	s.process.Wait()
+	if s.process == nil {
+		return
+	}
+
+	s.stopping = true
+	interruptProcess(s.process)
+
+	select {
+	case <-s.exited:
+	case <-time.After(s.gracePeriod()):
+		killProcess(s.process)
+		<-s.exited
+	}
+
+	s.process = nil
+}
+
+func (s *Service) gracePeriod() time.Duration {
+	if s.GracePeriod == 0 {
+		return DefaultGracePeriod
+	}
+	return s.GracePeriod
+}
+
+// Err returns an error wrapping types.ErrWebDriverExited if the WebDriver
+// exited without being stopped, or nil if it is still running.
+func (s *Service) Err() error {
+	if s.process == nil || s.stopping {
+		return nil
+	}
+
+	select {
+	case <-s.exited:
+	default:
+		return nil
+	}
+
+	if s.exitErr != nil {
+		return fmt.Errorf("%s %w: %s", s.name(), types.ErrWebDriverExited, s.exitErr)
+	}
+	return fmt.Errorf("%s %w", s.name(), types.ErrWebDriverExited)
+}
+
+func (s *Service) restart(ctx context.Context) error {
+	s.restarts++
 	s.process = nil
+	if err := s.StartContext(ctx); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", s.name(), err)
+	}
+	return nil
+}
+
+func (s *Service) CreateSession(capabilities *types.Capabilities) (*session.Session, error) {
+	return s.CreateSessionContext(context.Background(), capabilities)
 }
 
-func (s *Service) CreateSession(capabilities *Capabilities) (*session.Session, error) {
+func (s *Service) CreateSessionContext(ctx context.Context, capabilities *types.Capabilities) (*session.Session, error) {
 	if s.process == nil {
 		return nil, fmt.Errorf("%s not running", s.name())
 	}
 
-	capabilitiesJSON, _ := json.Marshal(capabilities)
-	desiredCapabilities := fmt.Sprintf(`{"desiredCapabilities": %s}`, capabilitiesJSON)
-	postBody := strings.NewReader(desiredCapabilities)
+	if err := s.Err(); err != nil {
+		if s.restarts >= s.MaxRestarts {
+			return nil, err
+		}
+		if err := s.restart(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	newSession, err := createSession(ctx, s.client(), s.WebDriverURL(), s.name(), capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	newSession.Health = s.Err
+	return newSession, nil
+}
+
+func (s *Service) client() *http.Client {
+	if s.Client == nil {
+		return &http.Client{}
+	}
+	return s.Client
+}
+
+func createSession(ctx context.Context, client *http.Client, url, name string, capabilities *types.Capabilities) (*session.Session, error) {
+	postBody, err := json.Marshal(newSessionRequest(capabilities))
+	if err != nil {
+		return nil, err
+	}
 
-	request, err := http.NewRequest("POST", fmt.Sprintf("%s/session", s.URL), postBody)
+	request, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/session", url), bytes.NewReader(postBody))
 	if err != nil {
 		return nil, err
 	}
 
-	client := &http.Client{}
 	response, err := client.Do(request)
 	if err != nil {
 		return nil, err
 	}
+	defer response.Body.Close()
 
-	var sessionResponse struct{ SessionID string }
+	var jsonWireResponse struct {
+		SessionID string
+		Status    int
+		Value     map[string]interface{}
+	}
+	var w3cResponse struct {
+		Value struct {
+			SessionID    string
+			Error        string
+			Capabilities map[string]interface{}
+		}
+	}
 
 	body, _ := ioutil.ReadAll(response.Body)
-	json.Unmarshal(body, &sessionResponse)
+	json.Unmarshal(body, &jsonWireResponse)
+	json.Unmarshal(body, &w3cResponse)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 || jsonWireResponse.Status != 0 || w3cResponse.Value.Error != "" {
+		err := session.DecodeError(response.StatusCode, body)
+		err.Method = "POST"
+		return nil, err
+	}
+
+	sessionID, dialect, negotiated := jsonWireResponse.SessionID, session.JSONWire, jsonWireResponse.Value
+	if sessionID == "" {
+		sessionID, dialect, negotiated = w3cResponse.Value.SessionID, session.W3C, w3cResponse.Value.Capabilities
+	}
 
-	if sessionResponse.SessionID == "" {
-		return nil, fmt.Errorf("%s webdriver failed to return a session ID", s.name())
+	if sessionID == "" {
+		return nil, fmt.Errorf("%s webdriver failed to return a session ID", name)
 	}
 
-	sessionURL := fmt.Sprintf("%s/session/%s", s.URL, sessionResponse.SessionID)
-	return &session.Session{URL: sessionURL}, nil
+	sessionURL := fmt.Sprintf("%s/session/%s", url, sessionID)
+	return &session.Session{URL: sessionURL, Dialect: dialect, Client: client, Capabilities: negotiated}, nil
 }
@@ -1,14 +1,22 @@
 package service_test
 
 import (
+	"bytes"
+	"errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti/core/internal/service"
+	"github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,10 +30,16 @@ var _ = Describe("Service", func() {
 	var (
 		service *Service
 		url     string
+		address string
 	)
 
+	killService := func() {
+		exec.Command("pkill", "-KILL", "-f", "webdriver="+address).Run()
+		Eventually(service.Err).Should(HaveOccurred())
+	}
+
 	BeforeEach(func() {
-		address := freeAddress()
+		address = freeAddress()
 		url = "http://" + address
 		service = &Service{
 			URL:     url,
@@ -76,12 +90,130 @@ var _ = Describe("Service", func() {
 			})
 		})
 
+		Context("when the service exits while processes it launched keep running", func() {
+			It("detects the exit without waiting for those processes", func() {
+				service.Command = []string{"sh", "-c", "sleep 5 & exit 1"}
+				start := time.Now()
+				err := service.Start()
+				Expect(err).To(MatchError(HavePrefix("sh webdriver failed to start: exit status 1")))
+				Expect(time.Since(start)).To(BeNumerically("<", 3*time.Second))
+			})
+		})
+
 		Context("when the service fails to start after the provided timeout", func() {
 			It("returns an error indicating that it failed to start", func() {
 				service.Timeout = 0
 				Expect(service.Start()).To(MatchError("phantomjs webdriver failed to start"))
 			})
 		})
+
+		Context("when the URL and command contain address placeholders", func() {
+			BeforeEach(func() {
+				service.URL = "http://{{address}}"
+				service.Command = []string{"phantomjs", "--webdriver={{host}}:{{port}}"}
+			})
+
+			AfterEach(func() {
+				service.Stop()
+			})
+
+			It("starts the service on a free address", func() {
+				Expect(service.Start()).To(Succeed())
+				Expect(service.WebDriverURL()).To(MatchRegexp(`^http://127\.0\.0\.1:[0-9]+$`))
+				_, err := http.Get(service.WebDriverURL() + "/status")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("starts the service on a port in the provided range", func() {
+				port := strings.SplitN(address, ":", 2)[1]
+				portNumber, _ := strconv.Atoi(port)
+				service.Ports = Ports{Min: portNumber, Max: portNumber}
+				Expect(service.Start()).To(Succeed())
+				Expect(service.WebDriverURL()).To(Equal(url))
+			})
+
+			It("retries on a new address when the address is already in use", func() {
+				markerDir, _ := ioutil.TempDir("", "marker")
+				defer os.RemoveAll(markerDir)
+				marker := filepath.Join(markerDir, "marker")
+				service.Command = []string{"sh", "-c", "if [ ! -f " + marker + " ]; then touch " + marker +
+					"; echo 'bind: Address already in use' >&2; exit 1; fi; exec phantomjs --webdriver={{address}}"}
+				Expect(service.Start()).To(Succeed())
+				_, err := http.Get(service.WebDriverURL() + "/status")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the service repeatedly fails", func() {
+				var attemptsPath string
+
+				attempts := func() int {
+					contents, _ := ioutil.ReadFile(attemptsPath)
+					return strings.Count(string(contents), "\n")
+				}
+
+				BeforeEach(func() {
+					attemptsDir, _ := ioutil.TempDir("", "attempts")
+					attemptsPath = filepath.Join(attemptsDir, "attempts")
+				})
+
+				AfterEach(func() {
+					os.RemoveAll(filepath.Dir(attemptsPath))
+				})
+
+				It("gives up after three addresses are already in use", func() {
+					service.Command = []string{"sh", "-c", "echo {{port}} >> " + attemptsPath + "; echo 'Address already in use'; exit 1"}
+					Expect(service.Start()).To(MatchError(ContainSubstring("sh webdriver failed to start")))
+					Expect(attempts()).To(Equal(3))
+				})
+
+				It("does not retry when the service fails for another reason", func() {
+					service.Command = []string{"sh", "-c", "echo {{port}} >> " + attemptsPath + "; exit 1"}
+					Expect(service.Start()).To(MatchError(ContainSubstring("sh webdriver failed to start")))
+					Expect(attempts()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the service exits before it starts", func() {
+			BeforeEach(func() {
+				service.Command = []string{"sh", "-c", "echo some output; echo some failure >&2; exit 3"}
+			})
+
+			It("returns an error with the exit status and the output of the service", func() {
+				err := service.Start()
+				Expect(err).To(MatchError("sh webdriver failed to start: exit status 3\noutput:\nsome output\nsome failure"))
+			})
+
+			It("runs the service with the provided environment variables", func() {
+				service.Command = []string{"sh", "-c", "echo $SOME_VARIABLE; exit 3"}
+				service.Env = []string{"SOME_VARIABLE=some value"}
+				Expect(service.Start()).To(MatchError(HaveSuffix("\nsome value")))
+			})
+
+			It("runs the service in the provided working directory", func() {
+				workingDir, _ := ioutil.TempDir("", "working")
+				defer os.RemoveAll(workingDir)
+				workingDir, _ = filepath.EvalSymlinks(workingDir)
+				service.Command = []string{"sh", "-c", "pwd; exit 3"}
+				service.Dir = workingDir
+				Expect(service.Start()).To(MatchError(HaveSuffix("\n" + workingDir)))
+			})
+
+			It("writes the output of the service to the provided writer", func() {
+				output := &bytes.Buffer{}
+				service.Output = output
+				service.Start()
+				Expect(output.String()).To(ContainSubstring("some failure"))
+			})
+
+			It("appends the output of the service to the provided output file", func() {
+				outputDir, _ := ioutil.TempDir("", "output")
+				defer os.RemoveAll(outputDir)
+				service.OutputPath = filepath.Join(outputDir, "output.log")
+				service.Start()
+				Expect(ioutil.ReadFile(service.OutputPath)).To(ContainSubstring("some output"))
+			})
+		})
 	})
 
 	Describe("#Stop", func() {
@@ -91,13 +223,51 @@ var _ = Describe("Service", func() {
 			_, err := http.Get(url + "/status")
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("does nothing when the service was never started", func() {
+			Expect(service.Stop).NotTo(Panic())
+		})
+
+		Context("when the service ignores the interrupt", func() {
+			It("kills the process group after the grace period", func() {
+				service.Command = []string{"sh", "-c", "trap '' INT; phantomjs --webdriver=" + address + "; sleep 60"}
+				service.GracePeriod = 100 * time.Millisecond
+				Expect(service.Start()).To(Succeed())
+				start := time.Now()
+				service.Stop()
+				Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+			})
+		})
+	})
+
+	Describe("#Err", func() {
+		It("returns nil while the service is running", func() {
+			defer service.Stop()
+			service.Start()
+			Expect(service.Err()).To(Succeed())
+		})
+
+		It("returns nil after the service is stopped", func() {
+			service.Start()
+			service.Stop()
+			Expect(service.Err()).To(Succeed())
+		})
+
+		It("returns an error when the service exits unexpectedly", func() {
+			defer service.Stop()
+			service.Start()
+			killService()
+			err := service.Err()
+			Expect(err).To(MatchError("phantomjs webdriver exited unexpectedly: signal: killed"))
+			Expect(errors.Is(err, types.ErrWebDriverExited)).To(BeTrue())
+		})
 	})
 
 	Describe("#CreateSession", func() {
-		var capabilities *Capabilities
+		var capabilities *types.Capabilities
 
 		BeforeEach(func() {
-			capabilities = &Capabilities{}
+			capabilities = types.NewCapabilities()
 		})
 
 		Context("with a running server", func() {
@@ -109,7 +279,94 @@ var _ = Describe("Service", func() {
 				service.Stop()
 			})
 
-			It("makes a POST request using the desired browser name", func() {
+			It("makes a POST request offering the desired browser name in both dialects", func() {
+				var requestBody string
+
+				fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					requestBodyBytes, _ := ioutil.ReadAll(request.Body)
+					requestBody = string(requestBodyBytes)
+				}))
+				defer fakeServer.Close()
+				service.URL = fakeServer.URL
+				capabilities.Browser("some-browser").Require("browserName")
+				service.CreateSession(capabilities)
+				Expect(requestBody).To(MatchJSON(`{
+					"desiredCapabilities": {"browserName": "some-browser"},
+					"requiredCapabilities": {"browserName": "some-browser"},
+					"capabilities": {"alwaysMatch": {"browserName": "some-browser"}, "firstMatch": [{}]}
+				}`))
+			})
+
+			It("makes a POST request where required capabilities take precedence over desired capabilities", func() {
+				var requestBody string
+
+				fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					requestBodyBytes, _ := ioutil.ReadAll(request.Body)
+					requestBody = string(requestBodyBytes)
+				}))
+				defer fakeServer.Close()
+				service.URL = fakeServer.URL
+				capabilities = &types.Capabilities{
+					Desired:  map[string]interface{}{"browserName": "some-other-browser"},
+					Required: map[string]interface{}{"browserName": "some-browser"},
+				}
+				service.CreateSession(capabilities)
+				Expect(requestBody).To(MatchJSON(`{
+					"desiredCapabilities": {"browserName": "some-browser"},
+					"requiredCapabilities": {"browserName": "some-browser"},
+					"capabilities": {"alwaysMatch": {"browserName": "some-browser"}, "firstMatch": [{}]}
+				}`))
+			})
+
+			It("makes a POST request with desired capabilities converted for W3C WebDrivers", func() {
+				var requestBody string
+
+				fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					requestBodyBytes, _ := ioutil.ReadAll(request.Body)
+					requestBody = string(requestBodyBytes)
+				}))
+				defer fakeServer.Close()
+				service.URL = fakeServer.URL
+				capabilities.Platform("LINUX").AcceptSSLCerts(true).
+					ChromeOptions(types.ChromeOptions{Args: []string{"--headless"}}).
+					With("javascriptEnabled", true)
+				service.CreateSession(capabilities)
+				Expect(requestBody).To(MatchJSON(`{
+					"desiredCapabilities": {
+						"platform": "LINUX",
+						"acceptSslCerts": true,
+						"chromeOptions": {"args": ["--headless"]},
+						"javascriptEnabled": true
+					},
+					"capabilities": {
+						"alwaysMatch": {},
+						"firstMatch": [{
+							"platformName": "linux",
+							"acceptInsecureCerts": true,
+							"goog:chromeOptions": {"args": ["--headless"]}
+						}, {}]
+					}
+				}`))
+			})
+
+			It("omits a platform of ANY for W3C WebDrivers", func() {
+				var requestBody string
+
+				fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					requestBodyBytes, _ := ioutil.ReadAll(request.Body)
+					requestBody = string(requestBodyBytes)
+				}))
+				defer fakeServer.Close()
+				service.URL = fakeServer.URL
+				capabilities.Platform("ANY")
+				service.CreateSession(capabilities)
+				Expect(requestBody).To(MatchJSON(`{
+					"desiredCapabilities": {"platform": "ANY"},
+					"capabilities": {"alwaysMatch": {}, "firstMatch": [{}]}
+				}`))
+			})
+
+			It("makes a POST request with vendor-prefixed browser options for W3C WebDrivers", func() {
 				var requestBody string
 
 				fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
@@ -118,9 +375,22 @@ var _ = Describe("Service", func() {
 				}))
 				defer fakeServer.Close()
 				service.URL = fakeServer.URL
-				capabilities.BrowserName = "some-browser"
+				capabilities.FirefoxOptions(types.FirefoxOptions{Args: []string{"-headless"}}).
+					EdgeOptions(types.ChromeOptions{Args: []string{"--headless"}})
 				service.CreateSession(capabilities)
-				Expect(requestBody).To(Equal(`{"desiredCapabilities": {"browserName":"some-browser"}}`))
+				Expect(requestBody).To(MatchJSON(`{
+					"desiredCapabilities": {
+						"moz:firefoxOptions": {"args": ["-headless"]},
+						"ms:edgeOptions": {"args": ["--headless"]}
+					},
+					"capabilities": {
+						"alwaysMatch": {},
+						"firstMatch": [{
+							"moz:firefoxOptions": {"args": ["-headless"]},
+							"ms:edgeOptions": {"args": ["--headless"]}
+						}, {}]
+					}
+				}`))
 			})
 
 			Context("if the request is invalid", func() {
@@ -151,11 +421,103 @@ var _ = Describe("Service", func() {
 				})
 			})
 
+			Context("if the WebDriver fails to create a session", func() {
+				It("returns the error reported by the WebDriver", func() {
+					fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+						response.WriteHeader(500)
+						response.Write([]byte(`{"value": {"error": "session not created", "message": "some message"}}`))
+					}))
+					defer fakeServer.Close()
+					service.URL = fakeServer.URL
+					_, err := service.CreateSession(capabilities)
+					Expect(err).To(MatchError("request unsuccessful: session not created: some message"))
+					var webDriverErr *types.WebDriverError
+					Expect(errors.As(err, &webDriverErr)).To(BeTrue())
+					Expect(webDriverErr.Code).To(Equal("session not created"))
+					Expect(webDriverErr.HTTPStatus).To(Equal(500))
+					Expect(webDriverErr.Method).To(Equal("POST"))
+				})
+
+				It("returns the error reported by a JSON Wire WebDriver", func() {
+					fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+						response.Write([]byte(`{"status": 33, "value": {"message": "{\"errorMessage\": \"some message\"}"}}`))
+					}))
+					defer fakeServer.Close()
+					service.URL = fakeServer.URL
+					_, err := service.CreateSession(capabilities)
+					var webDriverErr *types.WebDriverError
+					Expect(errors.As(err, &webDriverErr)).To(BeTrue())
+					Expect(webDriverErr.Status).To(Equal(33))
+					Expect(webDriverErr.Message).To(Equal("some message"))
+				})
+			})
+
+			Context("if the WebDriver responds in the W3C dialect", func() {
+				It("returns a W3C session with the session URL", func() {
+					fakeServer := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+						response.Write([]byte(`{"value": {"sessionId": "some-id", "capabilities": {}}}`))
+					}))
+					defer fakeServer.Close()
+					service.URL = fakeServer.URL
+					newSession, err := service.CreateSession(capabilities)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(newSession.URL).To(Equal(fakeServer.URL + "/session/some-id"))
+					Expect(newSession.Dialect).To(Equal(session.W3C))
+				})
+			})
+
 			Context("if the request succeeds", func() {
+				It("returns a session with the negotiated capabilities", func() {
+					newSession, _ := service.CreateSession(capabilities)
+					Expect(newSession.NegotiatedCapabilities()).NotTo(BeNil())
+				})
+
+				It("returns a session whose requests fail once the service exits", func() {
+					newSession, _ := service.CreateSession(capabilities)
+					killService()
+					err := newSession.Execute("url", "GET", nil, &struct{}{})
+					Expect(errors.Is(err, types.ErrWebDriverExited)).To(BeTrue())
+				})
+
 				It("returns a session with session URL", func() {
-					session, err := service.CreateSession(capabilities)
+					newSession, err := service.CreateSession(capabilities)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(newSession.URL).To(MatchRegexp(`http://127\.0\.0\.1:[0-9]+/session/([0-9a-f]+-)+[0-9a-f]+`))
+					Expect(newSession.Dialect).To(Equal(session.JSONWire))
+				})
+			})
+		})
+
+		Context("when the service has exited unexpectedly", func() {
+			BeforeEach(func() {
+				service.Start()
+				killService()
+			})
+
+			AfterEach(func() {
+				service.Stop()
+			})
+
+			It("returns an error indicating that the service exited", func() {
+				_, err := service.CreateSession(capabilities)
+				Expect(errors.Is(err, types.ErrWebDriverExited)).To(BeTrue())
+			})
+
+			Context("when restarts are enabled", func() {
+				It("restarts the service and returns a new session", func() {
+					service.MaxRestarts = 1
+					newSession, err := service.CreateSession(capabilities)
 					Expect(err).NotTo(HaveOccurred())
-					Expect(session.URL).To(MatchRegexp(`http://127\.0\.0\.1:[0-9]+/session/([0-9a-f]+-)+[0-9a-f]+`))
+					var url string
+					Expect(newSession.Execute("url", "GET", nil, &url)).To(Succeed())
+				})
+
+				It("returns an error once the restarts are exhausted", func() {
+					service.MaxRestarts = 1
+					service.CreateSession(capabilities)
+					killService()
+					_, err := service.CreateSession(capabilities)
+					Expect(errors.Is(err, types.ErrWebDriverExited)).To(BeTrue())
 				})
 			})
 		})
@@ -0,0 +1,374 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
+	"github.com/sclevine/agouti/core/internal/webdriver/storage"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// W3CElementKey is the key used by the W3C WebDriver protocol to identify web elements.
+const W3CElementKey = "element-6066-11e4-a52e-4f735466cecf"
+
+// Dialect adapts JSON Wire requests and responses to the protocol spoken by a WebDriver.
+type Dialect interface {
+	String() string
+	translate(endpoint, method string, body interface{}) (string, string, interface{}, error)
+	normalize(value []byte) ([]byte, error)
+	decodeError(httpStatus int, body []byte) *types.WebDriverError
+	alertText(body []byte) string
+	windowHandle(endpoint string) (string, bool)
+}
+
+var (
+	JSONWire Dialect = jsonWire{}
+	W3C      Dialect = w3c{}
+)
+
+// DecodeError returns the error reported by a failure response in either
+// dialect, such as a response to a request to create a session.
+func DecodeError(httpStatus int, body []byte) *types.WebDriverError {
+	if err := W3C.decodeError(httpStatus, body); err.Code != "" {
+		return err
+	}
+	return JSONWire.decodeError(httpStatus, body)
+}
+
+type jsonWire struct{}
+
+func (jsonWire) String() string {
+	return "JSON Wire"
+}
+
+func (jsonWire) translate(endpoint, method string, body interface{}) (string, string, interface{}, error) {
+	return endpoint, method, body, nil
+}
+
+func (jsonWire) normalize(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+func (jsonWire) decodeError(httpStatus int, body []byte) *types.WebDriverError {
+	var errBody struct {
+		Status int
+		Value  struct{ Message string }
+	}
+	if err := json.Unmarshal(body, &errBody); err != nil {
+		return unreadableError(httpStatus, body)
+	}
+
+	var errMessage struct{ ErrorMessage string }
+	if err := json.Unmarshal([]byte(errBody.Value.Message), &errMessage); err != nil {
+		return &types.WebDriverError{Status: errBody.Status, HTTPStatus: httpStatus, Message: "error message unreadable"}
+	}
+
+	return &types.WebDriverError{Status: errBody.Status, HTTPStatus: httpStatus, Message: errMessage.ErrorMessage}
+}
+
+func (jsonWire) windowHandle(endpoint string) (string, bool) {
+	return "", false
+}
+
+func (jsonWire) alertText(body []byte) string {
+	var errBody struct {
+		Value struct{ Alert struct{ Text string } }
+	}
+	json.Unmarshal(body, &errBody)
+	return errBody.Value.Alert.Text
+}
+
+type w3c struct{}
+
+var (
+	windowRectEndpoint  = regexp.MustCompile(`^window/([^/]+)/(?:size|position)$`)
+	windowStateEndpoint = regexp.MustCompile(`^window/([^/]+)/(maximize|minimize|fullscreen)$`)
+	attributeEndpoint   = regexp.MustCompile(`^element/([^/]+)/attribute/value$`)
+	equalsEndpoint      = regexp.MustCompile(`^element/([^/]+)/equals/([^/]+)$`)
+	submitEndpoint      = regexp.MustCompile(`^element/([^/]+)/submit$`)
+	valueEndpoint       = regexp.MustCompile(`^element/[^/]+/value$`)
+	storageEndpoint     = regexp.MustCompile(`^(local|session)_storage(?:/key/(.+))?$`)
+)
+
+const submitScript = `var form = arguments[0].form || arguments[0];
+if (!form.dispatchEvent(new Event("submit", {bubbles: true, cancelable: true}))) return;
+form.submit();`
+
+func (w3c) String() string {
+	return "W3C"
+}
+
+func (w w3c) translate(endpoint, method string, body interface{}) (string, string, interface{}, error) {
+	switch {
+	case endpoint == "window_handle":
+		return "window", method, body, nil
+	case endpoint == "window_handles":
+		return "window/handles", method, body, nil
+	case endpoint == "alert_text":
+		return "alert/text", method, body, nil
+	case endpoint == "accept_alert":
+		return "alert/accept", method, body, nil
+	case endpoint == "dismiss_alert":
+		return "alert/dismiss", method, body, nil
+	case endpoint == "log", endpoint == "log/types":
+		return "se/" + endpoint, method, body, nil
+	case endpoint == "frame":
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		request["id"] = w.elementReferences(request["id"])
+		return endpoint, method, request, nil
+	case windowRectEndpoint.MatchString(endpoint):
+		return "window/rect", method, body, nil
+	case windowStateEndpoint.MatchString(endpoint):
+		return "window/" + windowStateEndpoint.FindStringSubmatch(endpoint)[2], method, body, nil
+	case attributeEndpoint.MatchString(endpoint):
+		return "element/" + attributeEndpoint.FindStringSubmatch(endpoint)[1] + "/property/value", method, body, nil
+	case endpoint == "execute":
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		request["args"] = w.elementReferences(request["args"])
+		return "execute/sync", method, request, nil
+	case endpoint == "execute_async":
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		request["args"] = w.elementReferences(request["args"])
+		return "execute/async", method, request, nil
+	case endpoint == "timeouts" && method == "POST":
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return endpoint, method, w3cTimeouts(request), nil
+	case endpoint == "moveto":
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return "actions", method, moveToActions(request), nil
+	case endpoint == "doubleclick":
+		return "actions", method, doubleClickActions(), nil
+	case equalsEndpoint.MatchString(endpoint):
+		ids := equalsEndpoint.FindStringSubmatch(endpoint)
+		return "execute/sync", "POST", script("return arguments[0] === arguments[1];", ids[1], ids[2]), nil
+	case submitEndpoint.MatchString(endpoint):
+		id := submitEndpoint.FindStringSubmatch(endpoint)[1]
+		return "execute/sync", method, script(submitScript, id), nil
+	case valueEndpoint.MatchString(endpoint):
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if keys, ok := request["value"].([]interface{}); ok {
+			var text []string
+			for _, key := range keys {
+				text = append(text, fmt.Sprint(key))
+			}
+			request["text"] = strings.Join(text, "")
+		}
+		return endpoint, method, request, nil
+	case storageEndpoint.MatchString(endpoint):
+		return storageRequest(storageEndpoint.FindStringSubmatch(endpoint), method, body)
+	}
+
+	return endpoint, method, body, nil
+}
+
+// windowHandle returns the window that a request to a window endpoint refers to,
+// as W3C WebDrivers only resize, move and maximize the current window.
+func (w3c) windowHandle(endpoint string) (string, bool) {
+	if match := windowRectEndpoint.FindStringSubmatch(endpoint); match != nil {
+		return match[1], true
+	}
+	if match := windowStateEndpoint.FindStringSubmatch(endpoint); match != nil {
+		return match[1], true
+	}
+	return "", false
+}
+
+func (w w3c) elementReferences(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = w.elementReferences(item)
+		}
+		if id, ok := typed["ELEMENT"]; ok {
+			typed[W3CElementKey] = id
+		}
+	case []interface{}:
+		for index, item := range typed {
+			typed[index] = w.elementReferences(item)
+		}
+	}
+	return value
+}
+
+func (w3c) normalize(value []byte) ([]byte, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return nil, err
+	}
+	return json.Marshal(legacyElements(decoded))
+}
+
+func (w3c) decodeError(httpStatus int, body []byte) *types.WebDriverError {
+	var errBody struct {
+		Value struct {
+			Error   string
+			Message string
+		}
+	}
+	if err := json.Unmarshal(body, &errBody); err != nil || errBody.Value.Error == "" {
+		return unreadableError(httpStatus, body)
+	}
+
+	return &types.WebDriverError{Code: errBody.Value.Error, HTTPStatus: httpStatus, Message: errBody.Value.Message}
+}
+
+// unreadableError describes a failure response that is not in the format of
+// either dialect. Older WebDrivers reject endpoints they do not provide with
+// plain-text 404 and 405 responses, so those are unknown commands.
+func unreadableError(httpStatus int, body []byte) *types.WebDriverError {
+	if httpStatus != http.StatusNotFound && httpStatus != http.StatusMethodNotAllowed {
+		return &types.WebDriverError{HTTPStatus: httpStatus, Message: "error unreadable"}
+	}
+
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(httpStatus)
+	}
+	return &types.WebDriverError{Code: "unknown command", HTTPStatus: httpStatus, Message: message}
+}
+
+func (w3c) alertText(body []byte) string {
+	var errBody struct {
+		Value struct{ Data struct{ Text string } }
+	}
+	json.Unmarshal(body, &errBody)
+	return errBody.Value.Data.Text
+}
+
+func legacyElements(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if id, ok := typed[W3CElementKey]; ok && len(typed) == 1 {
+			return map[string]interface{}{"ELEMENT": id}
+		}
+		for key, item := range typed {
+			typed[key] = legacyElements(item)
+		}
+	case []interface{}:
+		for index, item := range typed {
+			typed[index] = legacyElements(item)
+		}
+	}
+	return value
+}
+
+func toMap(body interface{}) (map[string]interface{}, error) {
+	request := map[string]interface{}{}
+	if body == nil {
+		return request, nil
+	}
+
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bodyJSON, &request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func script(body string, elementIDs ...string) map[string]interface{} {
+	args := []interface{}{}
+	for _, id := range elementIDs {
+		args = append(args, map[string]string{"ELEMENT": id, W3CElementKey: id})
+	}
+	return map[string]interface{}{"script": body, "args": args}
+}
+
+// storageRequest translates the JSON Wire local_storage and session_storage
+// endpoints, which W3C WebDrivers do not provide, into scripts.
+func storageRequest(match []string, method string, body interface{}) (string, string, interface{}, error) {
+	args := []interface{}{match[1] + "Storage"}
+	scripts := storage.Scripts
+
+	if match[2] != "" {
+		key, err := url.PathUnescape(match[2])
+		if err != nil {
+			return "", "", nil, err
+		}
+		args = append(args, key)
+		scripts = storage.KeyScripts
+	} else if method == "POST" {
+		request, err := toMap(body)
+		if err != nil {
+			return "", "", nil, err
+		}
+		args = append(args, request["key"], request["value"])
+	}
+
+	source, ok := scripts[method]
+	if !ok {
+		return "", "", nil, fmt.Errorf("unsupported storage request: %s", method)
+	}
+	return "execute/sync", "POST", map[string]interface{}{"script": source, "args": args}, nil
+}
+
+// w3cTimeouts converts a JSON Wire timeout request, such as {"type": "page load", "ms": 1000},
+// to a W3C timeouts request, such as {"pageLoad": 1000}.
+func w3cTimeouts(request map[string]interface{}) map[string]interface{} {
+	timeoutType, ok := request["type"].(string)
+	if !ok {
+		return request
+	}
+
+	if timeoutType == "page load" {
+		timeoutType = "pageLoad"
+	}
+	return map[string]interface{}{timeoutType: request["ms"]}
+}
+
+func pointerActions(actions ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"actions": []interface{}{map[string]interface{}{
+			"type":       "pointer",
+			"id":         "mouse",
+			"parameters": map[string]string{"pointerType": "mouse"},
+			"actions":    actions,
+		}},
+	}
+}
+
+func moveToActions(request map[string]interface{}) map[string]interface{} {
+	move := map[string]interface{}{"type": "pointerMove", "duration": 0, "x": 0, "y": 0, "origin": "pointer"}
+
+	if id, ok := request["element"]; ok {
+		move["origin"] = map[string]interface{}{W3CElementKey: id}
+	}
+	if xoffset, ok := request["xoffset"]; ok {
+		move["x"] = xoffset
+	}
+	if yoffset, ok := request["yoffset"]; ok {
+		move["y"] = yoffset
+	}
+
+	return pointerActions(move)
+}
+
+func doubleClickActions() map[string]interface{} {
+	down := map[string]interface{}{"type": "pointerDown", "button": 0}
+	up := map[string]interface{}{"type": "pointerUp", "button": 0}
+	return pointerActions(down, up, down, up)
+}
@@ -0,0 +1,230 @@
+package session_test
+
+import (
+	. "github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
+
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Dialect", func() {
+	var (
+		requestPath   string
+		requestMethod string
+		requestBody   string
+		responseBody  string
+		status        int
+		session       *Session
+		server        *httptest.Server
+		err           error
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			requestPath = request.URL.Path
+			requestMethod = request.Method
+			requestBodyBytes, _ := ioutil.ReadAll(request.Body)
+			requestBody = string(requestBodyBytes)
+			response.WriteHeader(status)
+			response.Write([]byte(responseBody))
+		}))
+
+		session = &Session{URL: server.URL + "/session/some-id", Dialect: W3C}
+		responseBody = `{"value": null}`
+		status = 200
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("W3C", func() {
+		It("translates the window handle endpoint", func() {
+			session.Execute("window_handle", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/window"))
+		})
+
+		It("translates the window handles endpoint", func() {
+			session.Execute("window_handles", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/window/handles"))
+		})
+
+		Context("for the current window", func() {
+			BeforeEach(func() {
+				responseBody = `{"value": "some-window"}`
+			})
+
+			It("translates the window size endpoint", func() {
+				session.Execute("window/some-window/size", "POST", map[string]int{"width": 100, "height": 200}, &struct{}{})
+				Expect(requestPath).To(Equal("/session/some-id/window/rect"))
+				Expect(requestBody).To(MatchJSON(`{"width": 100, "height": 200}`))
+			})
+
+			It("translates the window position endpoint", func() {
+				session.Execute("window/some-window/position", "GET", nil, &struct{}{})
+				Expect(requestPath).To(Equal("/session/some-id/window/rect"))
+			})
+
+			It("translates the window state endpoints", func() {
+				session.Execute("window/some-window/maximize", "POST", struct{}{}, &struct{}{})
+				Expect(requestPath).To(Equal("/session/some-id/window/maximize"))
+				session.Execute("window/some-window/fullscreen", "POST", struct{}{}, &struct{}{})
+				Expect(requestPath).To(Equal("/session/some-id/window/fullscreen"))
+			})
+		})
+
+		Context("for a window other than the current window", func() {
+			It("returns an error without acting on the current window", func() {
+				responseBody = `{"value": "some-other-window"}`
+				err = session.Execute("window/some-window/size", "POST", map[string]int{"width": 100, "height": 200}, &struct{}{})
+				Expect(err).To(MatchError("window some-window is not the current window"))
+				Expect(requestPath).To(Equal("/session/some-id/window"))
+			})
+		})
+
+		It("translates the value attribute endpoint to the value property endpoint", func() {
+			session.Execute("element/some-id/attribute/value", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/element/some-id/property/value"))
+			session.Execute("element/some-id/attribute/class", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/element/some-id/attribute/class"))
+		})
+
+		It("translates the execute endpoint and its element arguments", func() {
+			body := map[string]interface{}{"script": "some script", "args": []interface{}{map[string]string{"ELEMENT": "some-id"}}}
+			session.Execute("execute", "POST", body, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/execute/sync"))
+			Expect(requestBody).To(MatchJSON(`{
+				"script": "some script",
+				"args": [{"ELEMENT": "some-id", "element-6066-11e4-a52e-4f735466cecf": "some-id"}]
+			}`))
+		})
+
+		It("translates the async execute endpoint and its element arguments", func() {
+			body := map[string]interface{}{"script": "some script", "args": []interface{}{map[string]string{"ELEMENT": "some-id"}}}
+			session.Execute("execute_async", "POST", body, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/execute/async"))
+			Expect(requestBody).To(MatchJSON(`{
+				"script": "some script",
+				"args": [{"ELEMENT": "some-id", "element-6066-11e4-a52e-4f735466cecf": "some-id"}]
+			}`))
+		})
+
+		It("translates timeout requests", func() {
+			session.Execute("timeouts", "POST", map[string]interface{}{"type": "page load", "ms": 1000}, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/timeouts"))
+			Expect(requestBody).To(MatchJSON(`{"pageLoad": 1000}`))
+			session.Execute("timeouts", "POST", map[string]interface{}{"type": "script", "ms": 2000}, &struct{}{})
+			Expect(requestBody).To(MatchJSON(`{"script": 2000}`))
+		})
+
+		It("leaves requests for the current timeouts unchanged", func() {
+			session.Execute("timeouts", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/timeouts"))
+			Expect(requestMethod).To(Equal("GET"))
+			Expect(requestBody).To(BeEmpty())
+		})
+
+		It("translates the element references used to switch frames", func() {
+			session.Execute("frame", "POST", map[string]interface{}{"id": map[string]string{"ELEMENT": "some-id"}}, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/frame"))
+			Expect(requestBody).To(MatchJSON(`{"id": {"ELEMENT": "some-id", "element-6066-11e4-a52e-4f735466cecf": "some-id"}}`))
+		})
+
+		It("translates mouse movement into pointer actions", func() {
+			session.Execute("moveto", "POST", map[string]interface{}{"element": "some-id", "xoffset": 5}, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/actions"))
+			Expect(requestBody).To(MatchJSON(`{"actions": [{
+				"type": "pointer",
+				"id": "mouse",
+				"parameters": {"pointerType": "mouse"},
+				"actions": [{"type": "pointerMove", "duration": 0, "x": 5, "y": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}}]
+			}]}`))
+		})
+
+		It("translates double-clicks into pointer actions", func() {
+			session.Execute("doubleclick", "POST", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/actions"))
+			Expect(requestBody).To(ContainSubstring(`"pointerDown"`))
+		})
+
+		It("translates element comparison into a script", func() {
+			session.Execute("element/some-id/equals/some-other-id", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/execute/sync"))
+			Expect(requestMethod).To(Equal("POST"))
+			Expect(requestBody).To(ContainSubstring(`"element-6066-11e4-a52e-4f735466cecf":"some-other-id"`))
+		})
+
+		It("adds the text to element value requests", func() {
+			session.Execute("element/some-id/value", "POST", map[string][]string{"value": {"a", "b"}}, &struct{}{})
+			Expect(requestBody).To(MatchJSON(`{"value": ["a", "b"], "text": "ab"}`))
+		})
+
+		It("returns W3C element references as JSON Wire element references", func() {
+			var result []struct{ Element string }
+			responseBody = `{"value": [{"element-6066-11e4-a52e-4f735466cecf": "some-element"}]}`
+			Expect(session.Execute("elements", "POST", nil, &result)).To(Succeed())
+			Expect(result[0].Element).To(Equal("some-element"))
+		})
+
+		It("translates the popup endpoints", func() {
+			session.Execute("alert_text", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/alert/text"))
+			session.Execute("accept_alert", "POST", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/alert/accept"))
+			session.Execute("dismiss_alert", "POST", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/alert/dismiss"))
+		})
+
+		It("translates the log endpoints", func() {
+			session.Execute("log", "POST", map[string]string{"type": "browser"}, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/se/log"))
+			Expect(requestBody).To(MatchJSON(`{"type": "browser"}`))
+			session.Execute("log/types", "GET", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/se/log/types"))
+		})
+
+		It("translates the storage endpoints into scripts", func() {
+			session.Execute("session_storage", "POST", map[string]string{"key": "some-key", "value": "some value"}, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/execute/sync"))
+			Expect(requestMethod).To(Equal("POST"))
+			Expect(requestBody).To(ContainSubstring("setItem(arguments[1], arguments[2])"))
+			Expect(requestBody).To(ContainSubstring(`"args":["sessionStorage","some-key","some value"]`))
+		})
+
+		It("translates the storage key endpoints into scripts", func() {
+			session.Execute("local_storage/key/some%2Fkey", "DELETE", nil, &struct{}{})
+			Expect(requestPath).To(Equal("/session/some-id/execute/sync"))
+			Expect(requestBody).To(ContainSubstring("removeItem(arguments[1])"))
+			Expect(requestBody).To(ContainSubstring(`"args":["localStorage","some/key"]`))
+		})
+
+		It("decodes W3C unexpected alert errors with the popup text", func() {
+			status = 500
+			responseBody = `{"value": {"error": "unexpected alert open", "message": "some message", "data": {"text": "some alert"}}}`
+			err = session.Execute("title", "GET", nil, &struct{}{})
+			var alertErr *types.UnexpectedAlertError
+			Expect(errors.As(err, &alertErr)).To(BeTrue())
+			Expect(alertErr.Text).To(Equal("some alert"))
+		})
+
+		It("decodes W3C unknown command errors", func() {
+			status = 404
+			responseBody = `{"value": {"error": "unknown command", "message": "some message"}}`
+			err = session.Execute("log", "POST", nil, &struct{}{})
+			Expect(errors.Is(err, types.ErrUnknownCommand)).To(BeTrue())
+		})
+
+		It("decodes W3C errors", func() {
+			status = 404
+			responseBody = `{"value": {"error": "no such element", "message": "some message"}}`
+			err = session.Execute("element", "POST", nil, &struct{}{})
+			Expect(err).To(MatchError("request unsuccessful: no such element: some message"))
+			Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+		})
+	})
+})
@@ -2,20 +2,165 @@ package session
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/sclevine/agouti/core/internal/types"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
+// DefaultTimeout bounds requests made without a context deadline.
+const DefaultTimeout = 60 * time.Second
+
+// scriptTimeouts are the timeouts that requests running scripts, loading pages
+// or finding elements with an implicit wait may legitimately take up to.
+var scriptTimeouts = map[string]bool{"script": true, "page load": true, "implicit": true}
+
 type Session struct {
-	URL string
+	URL          string
+	Dialect      Dialect
+	Timeout      time.Duration
+	Client       *http.Client
+	Capabilities map[string]interface{}
+
+	// Health, when provided, is checked before each request so that requests
+	// fail fast once the WebDriver serving the session is known to be gone.
+	Health func() error
+
+	// scriptTimeout is the longest script, page load or implicit timeout set
+	// through the session, which extends the timeout of requests made without a deadline.
+	scriptTimeout atomic.Int64
+}
+
+// Attach returns a session for an existing WebDriver session, detecting the
+// dialect it speaks from the shape of its response to a harmless request.
+func Attach(ctx context.Context, client *http.Client, url, sessionID string) (*Session, error) {
+	session := &Session{URL: fmt.Sprintf("%s/session/%s", url, sessionID), Client: client}
+	ctx, cancel := session.withDeadline(ctx)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, "GET", session.URL+"/url", nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %s", err)
+	}
+
+	response, err := session.client().Do(request)
+	if err != nil {
+		return nil, &types.TransportError{Endpoint: "url", Method: "GET", Err: err}
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := ioutil.ReadAll(response.Body)
+
+	var probe struct{ Status *int }
+	json.Unmarshal(responseBody, &probe)
+
+	session.Dialect = W3C
+	if probe.Status != nil {
+		session.Dialect = JSONWire
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		err := session.Dialect.decodeError(response.StatusCode, responseBody)
+		err.Endpoint = "url"
+		err.Method = "GET"
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// NegotiatedCapabilities returns the capabilities reported by the WebDriver when the session was created.
+func (s *Session) NegotiatedCapabilities() map[string]interface{} {
+	return s.Capabilities
+}
+
+func (s *Session) client() *http.Client {
+	if s.Client == nil {
+		return &http.Client{}
+	}
+	return s.Client
+}
+
+func (s *Session) dialect() Dialect {
+	if s.Dialect == nil {
+		return JSONWire
+	}
+	return s.Dialect
+}
+
+func (s *Session) timeout() time.Duration {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return timeout + time.Duration(s.scriptTimeout.Load())
+}
+
+// recordTimeout keeps track of the longest script, page load or implicit
+// timeout set by a JSON Wire timeouts request.
+func (s *Session) recordTimeout(endpoint, method string, body interface{}) {
+	if endpoint != "timeouts" || method != "POST" {
+		return
+	}
+
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return
+	}
+
+	var request struct {
+		Type string
+		MS   int64
+	}
+	if err := json.Unmarshal(bodyJSON, &request); err != nil || !scriptTimeouts[request.Type] {
+		return
+	}
+
+	timeout := int64(time.Duration(request.MS) * time.Millisecond)
+	for {
+		current := s.scriptTimeout.Load()
+		if timeout <= current || s.scriptTimeout.CompareAndSwap(current, timeout) {
+			return
+		}
+	}
+}
+
+func (s *Session) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.timeout())
 }
 
 func (s *Session) Execute(endpoint, method string, body, result interface{}) error {
-	client := &http.Client{}
+	return s.ExecuteContext(context.Background(), endpoint, method, body, result)
+}
+
+func (s *Session) ExecuteContext(ctx context.Context, endpoint, method string, body, result interface{}) error {
+	if s.Health != nil {
+		if err := s.Health(); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := s.withDeadline(ctx)
+	defer cancel()
+
+	if err := s.checkWindow(ctx, endpoint); err != nil {
+		return err
+	}
+
+	requestEndpoint, requestMethod, requestBody := endpoint, method, body
+	endpoint, method, body, err := s.dialect().translate(endpoint, method, body)
+	if err != nil {
+		return fmt.Errorf("invalid request body: %s", err)
+	}
 
 	var bodyReader io.Reader
 	if body != nil {
@@ -26,7 +171,7 @@ func (s *Session) Execute(endpoint, method string, body, result interface{}) err
 		bodyReader = bytes.NewReader(bodyJSON)
 	}
 
-	request, err := http.NewRequest(method, s.URL+"/"+endpoint, bodyReader)
+	request, err := http.NewRequestWithContext(ctx, method, s.URL+"/"+endpoint, bodyReader)
 	if err != nil {
 		return fmt.Errorf("invalid request: %s", err)
 	}
@@ -35,47 +180,83 @@ func (s *Session) Execute(endpoint, method string, body, result interface{}) err
 		request.Header.Add("Content-Type", "application/json")
 	}
 
-	response, err := client.Do(request)
+	response, err := s.client().Do(request)
 	if err != nil {
-		return fmt.Errorf("request failed: %s", err)
+		return &types.TransportError{Endpoint: endpoint, Method: method, Err: err}
 	}
+	defer response.Body.Close()
 
 	responseBody, _ := ioutil.ReadAll(response.Body)
 
 	if response.StatusCode < 200 || response.StatusCode > 299 {
-		var errBody struct{ Value struct{ Message string } }
-		if err := json.Unmarshal(responseBody, &errBody); err != nil {
-			return fmt.Errorf("request unsuccessful: error unreadable")
+		err := s.dialect().decodeError(response.StatusCode, responseBody)
+		err.Endpoint = endpoint
+		err.Method = method
+		if errors.Is(err, types.ErrUnexpectedAlert) {
+			return &types.UnexpectedAlertError{WebDriverError: err, Text: s.dialect().alertText(responseBody)}
 		}
+		return err
+	}
 
-		var errMessage struct{ ErrorMessage string }
-		if err := json.Unmarshal([]byte(errBody.Value.Message), &errMessage); err != nil {
-			return fmt.Errorf("request unsuccessful: error message unreadable")
-		}
+	s.recordTimeout(requestEndpoint, requestMethod, requestBody)
 
-		return fmt.Errorf("request unsuccessful: %s", errMessage.ErrorMessage)
+	var bodyValue struct{ Value json.RawMessage }
+	if err := json.Unmarshal(responseBody, &bodyValue); err != nil {
+		return fmt.Errorf("failed to parse response value: %s", err)
 	}
 
-	bodyValue := struct{ Value interface{} }{result}
+	if len(bodyValue.Value) == 0 || result == nil {
+		return nil
+	}
 
-	if err := json.Unmarshal(responseBody, &bodyValue); err != nil {
+	value, err := s.dialect().normalize(bodyValue.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse response value: %s", err)
+	}
+
+	if err := json.Unmarshal(value, result); err != nil {
 		return fmt.Errorf("failed to parse response value: %s", err)
 	}
 
 	return nil
 }
 
+// checkWindow returns an error when a request refers to a window other than the
+// current window, and the WebDriver only acts on the current window.
+func (s *Session) checkWindow(ctx context.Context, endpoint string) error {
+	handle, ok := s.dialect().windowHandle(endpoint)
+	if !ok {
+		return nil
+	}
+
+	var current string
+	if err := s.ExecuteContext(ctx, "window_handle", "GET", nil, &current); err != nil {
+		return err
+	}
+	if current != handle {
+		return fmt.Errorf("window %s is not the current window", handle)
+	}
+	return nil
+}
+
 func (s *Session) Destroy() error {
-	client := &http.Client{}
-	request, err := http.NewRequest("DELETE", s.URL, nil)
+	return s.DestroyContext(context.Background())
+}
+
+func (s *Session) DestroyContext(ctx context.Context) error {
+	ctx, cancel := s.withDeadline(ctx)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, "DELETE", s.URL, nil)
 	if err != nil {
 		return fmt.Errorf("invalid request: %s", err)
 	}
 
-	response, err := client.Do(request)
+	response, err := s.client().Do(request)
 	if err != nil {
-		return fmt.Errorf("request failed: %s", err)
+		return &types.TransportError{Method: "DELETE", Err: err}
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
 		return errors.New("failed to delete session")
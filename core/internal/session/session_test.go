@@ -2,12 +2,16 @@ package session_test
 
 import (
 	. "github.com/sclevine/agouti/core/internal/session"
+	"github.com/sclevine/agouti/core/internal/types"
 
+	"context"
+	"errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"time"
 )
 
 var _ = Describe("Session", func() {
@@ -35,7 +39,7 @@ var _ = Describe("Session", func() {
 			response.Write([]byte(responseBody))
 		}))
 
-		session = &Session{server.URL + "/session/some-id"}
+		session = &Session{URL: server.URL + "/session/some-id"}
 		responseBody = `{"value": {"some": "response value"}}`
 		responseStatus = 200
 	})
@@ -131,6 +135,12 @@ var _ = Describe("Session", func() {
 					Expect(err).To(MatchError("failed to parse response value: json: cannot unmarshal string into Go value of type struct { Some string }"))
 				})
 			})
+
+			Context("without a result", func() {
+				It("discards the response value without returning an error", func() {
+					Expect(session.Execute("some/endpoint", "POST", nil, nil)).To(Succeed())
+				})
+			})
 		})
 
 		Context("when the request fails entirely", func() {
@@ -139,6 +149,14 @@ var _ = Describe("Session", func() {
 				err = session.Execute("some/endpoint", "GET", nil, &result)
 				Expect(err.Error()).To(MatchRegexp("request failed: .+ connection refused"))
 			})
+
+			It("returns a transport error", func() {
+				server.Close()
+				err = session.Execute("some/endpoint", "GET", nil, &result)
+				var transportErr *types.TransportError
+				Expect(errors.As(err, &transportErr)).To(BeTrue())
+				Expect(transportErr.Endpoint).To(Equal("some/endpoint"))
+			})
 		})
 
 		Context("when the server responds with a non-2xx status code", func() {
@@ -151,6 +169,51 @@ var _ = Describe("Session", func() {
 				})
 			})
 
+			Context("when the server reports a JSON Wire status", func() {
+				BeforeEach(func() {
+					responseStatus = 500
+					responseBody = `{"status": 7, "value": {"message": "{\"errorMessage\": \"some error\"}"}}`
+					err = session.Execute("some/endpoint", "GET", nil, &result)
+				})
+
+				It("returns an error that matches the corresponding error kind", func() {
+					Expect(errors.Is(err, types.ErrNoSuchElement)).To(BeTrue())
+					Expect(errors.Is(err, types.ErrStaleElement)).To(BeFalse())
+				})
+
+				It("returns an error describing the failed request", func() {
+					var webDriverErr *types.WebDriverError
+					Expect(errors.As(err, &webDriverErr)).To(BeTrue())
+					Expect(webDriverErr.Status).To(Equal(7))
+					Expect(webDriverErr.HTTPStatus).To(Equal(500))
+					Expect(webDriverErr.Endpoint).To(Equal("some/endpoint"))
+					Expect(webDriverErr.Method).To(Equal("GET"))
+					Expect(webDriverErr.Message).To(Equal("some error"))
+				})
+			})
+
+			Context("when the server reports an open popup", func() {
+				BeforeEach(func() {
+					responseStatus = 500
+					responseBody = `{"status": 26, "value": {"message": "{\"errorMessage\": \"some error\"}", "alert": {"text": "some alert"}}}`
+					err = session.Execute("some/endpoint", "GET", nil, &result)
+				})
+
+				It("returns an unexpected alert error with the popup text", func() {
+					var alertErr *types.UnexpectedAlertError
+					Expect(errors.As(err, &alertErr)).To(BeTrue())
+					Expect(alertErr.Text).To(Equal("some alert"))
+					Expect(errors.Is(err, types.ErrUnexpectedAlert)).To(BeTrue())
+				})
+
+				It("returns an error describing the failed request", func() {
+					var webDriverErr *types.WebDriverError
+					Expect(errors.As(err, &webDriverErr)).To(BeTrue())
+					Expect(webDriverErr.Endpoint).To(Equal("some/endpoint"))
+					Expect(err).To(MatchError("request unsuccessful: some error"))
+				})
+			})
+
 			Context("when the server does not have a valid message", func() {
 				It("returns an error from the server indicating that the request failed", func() {
 					responseStatus = 400
@@ -160,6 +223,21 @@ var _ = Describe("Session", func() {
 				})
 			})
 
+			Context("when the server rejects the endpoint with a plain-text response", func() {
+				It("returns an unknown command error with the response text", func() {
+					responseStatus = 404
+					responseBody = "Unknown command: some/endpoint\n"
+					err = session.Execute("some/endpoint", "GET", nil, &result)
+					Expect(errors.Is(err, types.ErrUnknownCommand)).To(BeTrue())
+					Expect(err).To(MatchError("request unsuccessful: unknown command: Unknown command: some/endpoint"))
+
+					responseStatus = 405
+					session.Dialect = W3C
+					err = session.Execute("some/endpoint", "GET", nil, &result)
+					Expect(errors.Is(err, types.ErrUnknownCommand)).To(BeTrue())
+				})
+			})
+
 			Context("when the server does not have a valid error message", func() {
 				It("returns an error from the server indicating that the request failed", func() {
 					responseStatus = 400
@@ -171,6 +249,98 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#ExecuteContext", func() {
+		It("makes a request with the provided context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err = session.ExecuteContext(ctx, "some/endpoint", "GET", nil, &result)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		})
+
+		Context("when the provided context has no deadline", func() {
+			It("gives up after the session timeout", func() {
+				session.Timeout = time.Nanosecond
+				err = session.ExecuteContext(context.Background(), "some/endpoint", "GET", nil, &result)
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			})
+
+			It("extends the session timeout by the longest script or page load timeout", func() {
+				timeout := struct {
+					Type string `json:"type"`
+					MS   int64  `json:"ms"`
+				}{"script", 60000}
+				Expect(session.ExecuteContext(context.Background(), "timeouts", "POST", timeout, &result)).To(Succeed())
+				session.Timeout = time.Nanosecond
+				Expect(session.ExecuteContext(context.Background(), "some/endpoint", "GET", nil, &result)).To(Succeed())
+			})
+
+			It("extends the session timeout by the implicit wait", func() {
+				timeout := struct {
+					Type string `json:"type"`
+					MS   int64  `json:"ms"`
+				}{"implicit", 60000}
+				Expect(session.ExecuteContext(context.Background(), "timeouts", "POST", timeout, &result)).To(Succeed())
+				session.Timeout = time.Nanosecond
+				Expect(session.ExecuteContext(context.Background(), "elements", "POST", nil, &result)).To(Succeed())
+			})
+
+			It("does not extend the session timeout by other timeouts", func() {
+				timeout := struct {
+					Type string `json:"type"`
+					MS   int64  `json:"ms"`
+				}{"some timeout", 60000}
+				Expect(session.ExecuteContext(context.Background(), "timeouts", "POST", timeout, &result)).To(Succeed())
+				session.Timeout = time.Nanosecond
+				err = session.ExecuteContext(context.Background(), "some/endpoint", "GET", nil, &result)
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			})
+		})
+
+		Context("when the session health check fails", func() {
+			It("returns the health check error without making a request", func() {
+				requestPath = ""
+				session.Health = func() error { return errors.New("some health error") }
+				err = session.ExecuteContext(context.Background(), "some/endpoint", "GET", nil, &result)
+				Expect(err).To(MatchError("some health error"))
+				Expect(requestPath).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe(".Attach", func() {
+		It("returns a session at the provided session ID", func() {
+			attached, err := Attach(context.Background(), nil, server.URL, "some-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attached.URL).To(Equal(server.URL + "/session/some-id"))
+			Expect(requestPath).To(Equal("/session/some-id/url"))
+		})
+
+		Context("when the WebDriver responds in the JSON Wire dialect", func() {
+			It("returns a JSON Wire session", func() {
+				responseBody = `{"status": 0, "value": "http://example.com"}`
+				attached, _ := Attach(context.Background(), nil, server.URL, "some-id")
+				Expect(attached.Dialect).To(Equal(JSONWire))
+			})
+		})
+
+		Context("when the WebDriver responds in the W3C dialect", func() {
+			It("returns a W3C session", func() {
+				responseBody = `{"value": "http://example.com"}`
+				attached, _ := Attach(context.Background(), nil, server.URL, "some-id")
+				Expect(attached.Dialect).To(Equal(W3C))
+			})
+		})
+
+		Context("when the session does not exist", func() {
+			It("returns an error", func() {
+				responseStatus = 404
+				responseBody = `{"value": {"error": "invalid session id", "message": "some message"}}`
+				_, err := Attach(context.Background(), nil, server.URL, "some-id")
+				Expect(errors.Is(err, types.ErrSessionNotFound)).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("#Destroy", func() {
 		BeforeEach(func() {
 			err = session.Destroy()
@@ -206,6 +376,16 @@ var _ = Describe("Session", func() {
 				err = session.Destroy()
 				Expect(err.Error()).To(MatchRegexp("request failed: .+ connection refused"))
 			})
+
+			It("returns a transport error for the session itself", func() {
+				server.Close()
+				err = session.Destroy()
+				var transportErr *types.TransportError
+				Expect(errors.As(err, &transportErr)).To(BeTrue())
+				Expect(transportErr.Endpoint).To(BeEmpty())
+				Expect(transportErr.Method).To(Equal("DELETE"))
+				Expect(transportErr.Err).To(MatchError(ContainSubstring(session.URL)))
+			})
 		})
 
 		Context("when the server responds with a non-2xx status code", func() {
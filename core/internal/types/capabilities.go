@@ -0,0 +1,116 @@
+package types
+
+// Capabilities describes the features requested from a WebDriver when creating a session.
+// Desired capabilities are satisfied when possible, while required capabilities must be
+// satisfied for the session to be created.
+type Capabilities struct {
+	Desired  map[string]interface{}
+	Required map[string]interface{}
+}
+
+type ChromeOptions struct {
+	Args            []string               `json:"args,omitempty"`
+	Binary          string                 `json:"binary,omitempty"`
+	Extensions      []string               `json:"extensions,omitempty"`
+	Prefs           map[string]interface{} `json:"prefs,omitempty"`
+	MobileEmulation map[string]interface{} `json:"mobileEmulation,omitempty"`
+}
+
+type FirefoxOptions struct {
+	Args    []string               `json:"args,omitempty"`
+	Binary  string                 `json:"binary,omitempty"`
+	Profile string                 `json:"profile,omitempty"`
+	Prefs   map[string]interface{} `json:"prefs,omitempty"`
+	Log     map[string]string      `json:"log,omitempty"`
+}
+
+type Proxy struct {
+	ProxyType          string   `json:"proxyType"`
+	ProxyAutoconfigURL string   `json:"proxyAutoconfigUrl,omitempty"`
+	HTTPProxy          string   `json:"httpProxy,omitempty"`
+	SSLProxy           string   `json:"sslProxy,omitempty"`
+	FTPProxy           string   `json:"ftpProxy,omitempty"`
+	SOCKSProxy         string   `json:"socksProxy,omitempty"`
+	SOCKSVersion       int      `json:"socksVersion,omitempty"`
+	NoProxy            []string `json:"noProxy,omitempty"`
+}
+
+func NewCapabilities() *Capabilities {
+	return &Capabilities{Desired: map[string]interface{}{}, Required: map[string]interface{}{}}
+}
+
+// With sets an arbitrary desired capability, such as a vendor-specific key.
+// Required capabilities take precedence, so With does not change a capability
+// that is already required.
+func (c *Capabilities) With(key string, value interface{}) *Capabilities {
+	if _, ok := c.Required[key]; ok {
+		return c
+	}
+	if c.Desired == nil {
+		c.Desired = map[string]interface{}{}
+	}
+	c.Desired[key] = value
+	return c
+}
+
+// Require moves the provided capabilities from desired to required.
+func (c *Capabilities) Require(keys ...string) *Capabilities {
+	for _, key := range keys {
+		if value, ok := c.Desired[key]; ok {
+			if c.Required == nil {
+				c.Required = map[string]interface{}{}
+			}
+			c.Required[key] = value
+			delete(c.Desired, key)
+		}
+	}
+	return c
+}
+
+func (c *Capabilities) Browser(name string) *Capabilities {
+	return c.With("browserName", name)
+}
+
+func (c *Capabilities) Version(version string) *Capabilities {
+	return c.With("version", version)
+}
+
+func (c *Capabilities) Platform(platform string) *Capabilities {
+	return c.With("platform", platform)
+}
+
+func (c *Capabilities) AcceptSSLCerts(accept bool) *Capabilities {
+	return c.With("acceptSslCerts", accept)
+}
+
+// UnexpectedAlertBehaviour is one of "accept", "dismiss" or "ignore".
+func (c *Capabilities) UnexpectedAlertBehaviour(behaviour string) *Capabilities {
+	return c.With("unexpectedAlertBehaviour", behaviour)
+}
+
+// PageLoadStrategy is one of "normal", "eager" or "none".
+func (c *Capabilities) PageLoadStrategy(strategy string) *Capabilities {
+	return c.With("pageLoadStrategy", strategy)
+}
+
+func (c *Capabilities) Proxy(proxy Proxy) *Capabilities {
+	return c.With("proxy", proxy)
+}
+
+// LoggingPrefs maps log types (such as "browser" or "driver") to log levels (such as "ALL" or "SEVERE").
+func (c *Capabilities) LoggingPrefs(prefs map[string]string) *Capabilities {
+	return c.With("loggingPrefs", prefs)
+}
+
+func (c *Capabilities) ChromeOptions(options ChromeOptions) *Capabilities {
+	return c.With("chromeOptions", options)
+}
+
+func (c *Capabilities) FirefoxOptions(options FirefoxOptions) *Capabilities {
+	return c.With("moz:firefoxOptions", options)
+}
+
+// EdgeOptions configures msedgedriver, which accepts the same options as ChromeDriver.
+func (c *Capabilities) EdgeOptions(options ChromeOptions) *Capabilities {
+	return c.With("ms:edgeOptions", options)
+}
@@ -1,11 +1,79 @@
 package types
 
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Cookie is a browser cookie. Cookies without an expiry are session cookies.
 type Cookie struct {
-	Name     string      `json:"name"`
-	Value    interface{} `json:"value"`
-	Path     string      `json:"path"`
-	Domain   string      `json:"domain"`
-	Secure   bool        `json:"secure"`
-	HTTPOnly bool        `json:"httpOnly"`
-	Expiry   int64       `json:"expiry"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path"`
+	Domain   string `json:"domain"`
+	Secure   bool   `json:"secure"`
+	HTTPOnly bool   `json:"httpOnly"`
+	Expiry   int64  `json:"expiry,omitempty"`
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// UnmarshalJSON decodes a cookie, truncating a fractional expiry to whole seconds.
+func (c *Cookie) UnmarshalJSON(data []byte) error {
+	type cookie Cookie
+	decoded := struct {
+		*cookie
+		Expiry float64 `json:"expiry"`
+	}{cookie: (*cookie)(c)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	c.Expiry = int64(decoded.Expiry)
+	return nil
+}
+
+var sameSiteModes = map[string]http.SameSite{
+	"Lax":    http.SameSiteLaxMode,
+	"Strict": http.SameSiteStrictMode,
+	"None":   http.SameSiteNoneMode,
+}
+
+// HTTPCookie converts the cookie to a *http.Cookie. Cookies without an
+// expiry are returned as session cookies.
+func (c *Cookie) HTTPCookie() *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+		SameSite: sameSiteModes[c.SameSite],
+	}
+	if c.Expiry != 0 {
+		cookie.Expires = time.Unix(c.Expiry, 0)
+	}
+	return cookie
+}
+
+// CookieFromHTTP converts a *http.Cookie to a cookie that may be set in a browser.
+func CookieFromHTTP(cookie *http.Cookie) *Cookie {
+	converted := &Cookie{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		Domain:   cookie.Domain,
+		Secure:   cookie.Secure,
+		HTTPOnly: cookie.HttpOnly,
+	}
+	if !cookie.Expires.IsZero() {
+		converted.Expiry = cookie.Expires.Unix()
+	}
+	for name, mode := range sameSiteModes {
+		if cookie.SameSite == mode {
+			converted.SameSite = name
+		}
+	}
+	return converted
 }
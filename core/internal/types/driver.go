@@ -0,0 +1,45 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+type Driver interface {
+	GetCapabilities() (map[string]interface{}, error)
+	GetWindow() (Window, error)
+	GetWindows() ([]Window, error)
+	SetWindow(window Window) error
+	NewWindow() (Window, error)
+	DeleteWindow() error
+	SetFrame(frame Element) error
+	SetParentFrame() error
+	GetAlertText() (string, error)
+	SetAlertText(text string) error
+	AcceptAlert() error
+	DismissAlert() error
+	GetScreenshot() ([]byte, error)
+	GetCookies() ([]*Cookie, error)
+	SetCookie(cookie *Cookie) error
+	DeleteCookie(name string) error
+	DeleteCookies() error
+	GetLocalStorage() Storage
+	GetSessionStorage() Storage
+	GetURL() (string, error)
+	SetURL(url string) error
+	GetTitle() (string, error)
+	GetSource() (string, error)
+	GetLogs(logType string) ([]Log, error)
+	GetLogTypes() ([]string, error)
+	GetElements(selector Selector) ([]Element, error)
+	DoubleClick() error
+	MoveTo(element Element, point Point) error
+	Execute(body string, arguments []interface{}, result interface{}) error
+	ExecuteAsync(body string, arguments []interface{}, result interface{}) error
+	SetTimeout(timeoutType string, timeout time.Duration) error
+	GetTimeout(timeoutType string) (time.Duration, error)
+	Forward() error
+	Back() error
+	Refresh() error
+	WithContext(ctx context.Context) Driver
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoSuchElement     = errors.New("no such element")
+	ErrStaleElement      = errors.New("stale element reference")
+	ErrElementNotVisible = errors.New("element not visible")
+	ErrTimeout           = errors.New("timeout")
+	ErrUnexpectedAlert   = errors.New("unexpected alert open")
+	ErrNoSuchAlert       = errors.New("no such alert")
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrUnknownCommand    = errors.New("unknown command")
+	ErrWebDriverExited   = errors.New("webdriver exited unexpectedly")
+)
+
+var w3cErrors = map[string]error{
+	"no such element":          ErrNoSuchElement,
+	"stale element reference":  ErrStaleElement,
+	"element not visible":      ErrElementNotVisible,
+	"element not interactable": ErrElementNotVisible,
+	"timeout":                  ErrTimeout,
+	"script timeout":           ErrTimeout,
+	"unexpected alert open":    ErrUnexpectedAlert,
+	"no such alert":            ErrNoSuchAlert,
+	"invalid session id":       ErrSessionNotFound,
+	"unknown command":          ErrUnknownCommand,
+	"unknown method":           ErrUnknownCommand,
+}
+
+var jsonWireErrors = map[int]error{
+	6:  ErrSessionNotFound,
+	7:  ErrNoSuchElement,
+	9:  ErrUnknownCommand,
+	10: ErrStaleElement,
+	11: ErrElementNotVisible,
+	21: ErrTimeout,
+	26: ErrUnexpectedAlert,
+	27: ErrNoSuchAlert,
+	28: ErrTimeout,
+}
+
+// WebDriverError is returned when a WebDriver responds to a request with a failure.
+type WebDriverError struct {
+	Code       string
+	Status     int
+	HTTPStatus int
+
+	// Endpoint is relative to the session URL, and is empty for requests that
+	// create or delete the session itself. The same applies to TransportError.
+	Endpoint string
+	Method   string
+	Message  string
+}
+
+func (e *WebDriverError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("request unsuccessful: %s: %s", e.Code, e.Message)
+	}
+	return "request unsuccessful: " + e.Message
+}
+
+func (e *WebDriverError) Unwrap() error {
+	if err, ok := w3cErrors[e.Code]; ok {
+		return err
+	}
+	return jsonWireErrors[e.Status]
+}
+
+// UnexpectedAlertError is returned when a request fails because a JavaScript
+// alert, confirm or prompt popup is open. Text is the message of the popup, if reported.
+type UnexpectedAlertError struct {
+	*WebDriverError
+	Text string
+}
+
+func (e *UnexpectedAlertError) Unwrap() error {
+	return e.WebDriverError
+}
+
+// TransportError is returned when a request never receives a response from a
+// WebDriver. Err describes the failed request, including its URL.
+type TransportError struct {
+	Endpoint string
+	Method   string
+	Err      error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("request failed: %s", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// MultipleElementsFoundError is returned when a selection that must refer to
+// a single element refers to more than one.
+type MultipleElementsFoundError struct {
+	Selector string
+	Count    int
+}
+
+func (e *MultipleElementsFoundError) Error() string {
+	return fmt.Sprintf("mutiple elements (%d) were selected", e.Count)
+}
+
+// NoElementFoundError is returned when a selection that must refer to
+// a single element does not refer to any.
+type NoElementFoundError struct {
+	Selector string
+}
+
+func (e *NoElementFoundError) Error() string {
+	return "no element found"
+}
+
+func (e *NoElementFoundError) Unwrap() error {
+	return ErrNoSuchElement
+}
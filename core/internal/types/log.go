@@ -0,0 +1,10 @@
+package types
+
+import "time"
+
+// Log is an entry read from one of the browser logs.
+type Log struct {
+	Message string
+	Level   string
+	Time    time.Time
+}
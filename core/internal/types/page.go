@@ -1,20 +1,70 @@
 package types
 
+import (
+	"context"
+	"image"
+	"io"
+	"time"
+)
+
 type Page interface {
+	WithContext(ctx context.Context) Page
+	WithTimeout(timeout time.Duration) Page
+	WithPollInterval(interval time.Duration) Page
+	Capabilities() (map[string]interface{}, error)
 	Navigate(url string) error
+	GetCookies() ([]*Cookie, error)
+	AddCookie(cookie *Cookie) error
 	SetCookie(name string, value interface{}, path, domain string, secure, httpOnly bool, expiry int64) error
 	DeleteCookie(name string) error
 	ClearCookies() error
+	LocalStorage() Storage
+	SessionStorage() Storage
+	SaveState(w io.Writer) error
+	LoadState(r io.Reader) error
 	URL() (string, error)
 	Size(width, height int) error
-	Screenshot(filename string) error
+	WindowSize() (width, height int, err error)
+	WindowPosition() (x, y int, err error)
+	SetWindowPosition(x, y int) error
+	Maximize() error
+	Minimize() error
+	Fullscreen() error
+	SetViewport(width, height int) error
+	Windows() ([]string, error)
+	SwitchToWindow(handle string) error
+	SwitchToWindowByTitle(title string) error
+	SwitchToWindowByURL(url string) error
+	NewWindow() (string, error)
+	CloseWindow() error
+	WaitForNewWindow(timeout time.Duration, action func() error) (string, error)
+	Screenshot(filename string, highlight ...Selection) error
+	ScreenshotImage(highlight ...Selection) (image.Image, error)
 	Title() (string, error)
 	HTML() (string, error)
+	PopupText() (string, error)
+	EnterPopupText(text string) error
+	ConfirmPopup() error
+	CancelPopup() error
 	RunScript(body string, arguments map[string]interface{}, result interface{}) error
+	RunAsyncScript(body string, arguments map[string]interface{}, result interface{}) error
+	AwaitScript(body string, arguments map[string]interface{}, result interface{}) error
+	SetPageLoadTimeout(timeout time.Duration) error
+	SetScriptTimeout(timeout time.Duration) error
+	SetImplicitWait(timeout time.Duration) error
+	PageLoadTimeout() (time.Duration, error)
+	ScriptTimeout() (time.Duration, error)
+	ImplicitWait() (time.Duration, error)
+	ReadLogs(logType string) ([]Log, error)
+	LogTypes() ([]string, error)
 	Forward() error
 	Back() error
 	Refresh() error
 	Find(selector string) Selection
 	FindXPath(selector string) Selection
 	FindByLabel(text string) Selection
+	Frame(selector string) Selection
+	SwitchToFrame(frame Selection) error
+	SwitchToParentFrame() error
+	SwitchToRootFrame() error
 }
@@ -1,9 +1,26 @@
 package types
 
+import (
+	"context"
+	"image"
+	"time"
+)
+
 type Selection interface {
+	WithContext(ctx context.Context) Selection
+	WithTimeout(timeout time.Duration) Selection
+	WithPollInterval(interval time.Duration) Selection
 	Find(selector string) Selection
 	FindXPath(selector string) Selection
 	FindByLabel(text string) Selection
+	At(index int) Selection
+	First() Selection
+	Last() Selection
+	All() ([]Selection, error)
+	Elements() ([]Selection, error)
+	Each(iterator func(index int, selection Selection) error) error
+	Frame(selector string) Selection
+	SwitchToFrame() error
 	String() string
 	Count() (int, error)
 	Click() error
@@ -17,7 +34,13 @@ type Selection interface {
 	Selected() (bool, error)
 	Visible() (bool, error)
 	Enabled() (bool, error)
+	Texts() ([]string, error)
+	Attributes(attribute string) ([]string, error)
+	CSSValues(property string) ([]string, error)
+	Visibilities() ([]bool, error)
 	Select(text string) error
 	Submit() error
 	EqualsElement(comparable interface{}) (bool, error)
+	Screenshot(filename string) error
+	ScreenshotImage() (image.Image, error)
 }
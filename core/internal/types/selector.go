@@ -1,11 +1,22 @@
 package types
 
+import "strconv"
+
+// Selector finds elements using the Using strategy, or, when Indexed is set,
+// picks the element at Index out of the elements found so far.
+// A negative Index counts back from the last element.
 type Selector struct {
-	Using string `json:"using"`
-	Value string `json:"value"`
+	Using   string `json:"using"`
+	Value   string `json:"value"`
+	Index   int    `json:"-"`
+	Indexed bool   `json:"-"`
 }
 
 func (s Selector) String() string {
+	if s.Indexed {
+		return "[" + strconv.Itoa(s.Index) + "]"
+	}
+
 	switch s.Using {
 	case "css selector":
 		return "CSS: " + s.Value
@@ -0,0 +1,9 @@
+package types
+
+type Storage interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+	Keys() ([]string, error)
+	Clear() error
+}
@@ -1,5 +1,12 @@
 package types
 
 type Window interface {
-	SetSize(height, width int) error
+	GetID() string
+	GetSize() (width, height int, err error)
+	SetSize(width, height int) error
+	GetPosition() (x, y int, err error)
+	SetPosition(x, y int) error
+	Maximize() error
+	Minimize() error
+	Fullscreen() error
 }
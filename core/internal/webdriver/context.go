@@ -0,0 +1,30 @@
+package webdriver
+
+import "context"
+
+type contextExecutable interface {
+	executable
+	ExecuteContext(ctx context.Context, endpoint, method string, body, result interface{}) error
+}
+
+// contextSession binds a context to every request made through a session,
+// including requests made by elements and windows retrieved through it.
+type contextSession struct {
+	ctx     context.Context
+	session contextExecutable
+}
+
+func (c *contextSession) Execute(endpoint, method string, body, result interface{}) error {
+	return c.session.ExecuteContext(c.ctx, endpoint, method, body, result)
+}
+
+func (c *contextSession) ExecuteContext(ctx context.Context, endpoint, method string, body, result interface{}) error {
+	return c.session.ExecuteContext(ctx, endpoint, method, body, result)
+}
+
+func (c *contextSession) NegotiatedCapabilities() map[string]interface{} {
+	if session, ok := c.session.(negotiator); ok {
+		return session.NegotiatedCapabilities()
+	}
+	return nil
+}
@@ -1,10 +1,15 @@
 package webdriver
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"github.com/sclevine/agouti/core/internal/types"
 	"github.com/sclevine/agouti/core/internal/webdriver/element"
+	"github.com/sclevine/agouti/core/internal/webdriver/storage"
 	"github.com/sclevine/agouti/core/internal/webdriver/window"
+	"time"
 )
 
 type Driver struct {
@@ -15,6 +20,30 @@ type executable interface {
 	Execute(endpoint, method string, body, result interface{}) error
 }
 
+func (d *Driver) WithContext(ctx context.Context) types.Driver {
+	session, ok := d.Session.(contextExecutable)
+	if !ok {
+		return d
+	}
+
+	if bound, ok := session.(*contextSession); ok {
+		session = bound.session
+	}
+
+	return &Driver{Session: &contextSession{ctx, session}}
+}
+
+type negotiator interface {
+	NegotiatedCapabilities() map[string]interface{}
+}
+
+func (d *Driver) GetCapabilities() (map[string]interface{}, error) {
+	if session, ok := d.Session.(negotiator); ok && session.NegotiatedCapabilities() != nil {
+		return session.NegotiatedCapabilities(), nil
+	}
+	return nil, errors.New("session was not created with negotiated capabilities")
+}
+
 func (d *Driver) GetElements(selector types.Selector) ([]types.Element, error) {
 	var results []struct{ Element string }
 
@@ -38,6 +67,99 @@ func (d *Driver) GetWindow() (types.Window, error) {
 	return &window.Window{ID: windowID, Session: d.Session}, nil
 }
 
+func (d *Driver) GetWindows() ([]types.Window, error) {
+	var windowIDs []string
+	if err := d.Session.Execute("window_handles", "GET", nil, &windowIDs); err != nil {
+		return nil, err
+	}
+
+	windows := []types.Window{}
+	for _, windowID := range windowIDs {
+		windows = append(windows, &window.Window{ID: windowID, Session: d.Session})
+	}
+
+	return windows, nil
+}
+
+func (d *Driver) SetWindow(window types.Window) error {
+	request := struct {
+		Name   string `json:"name"`
+		Handle string `json:"handle"`
+	}{window.GetID(), window.GetID()}
+
+	return d.Session.Execute("window", "POST", request, &struct{}{})
+}
+
+// NewWindow opens a new top-level browsing context with the W3C /window/new
+// endpoint, without switching to it.
+func (d *Driver) NewWindow() (types.Window, error) {
+	request := struct {
+		Type string `json:"type"`
+	}{"window"}
+
+	var result struct {
+		Handle string `json:"handle"`
+	}
+	if err := d.Session.Execute("window/new", "POST", request, &result); err != nil {
+		return nil, err
+	}
+	return &window.Window{ID: result.Handle, Session: d.Session}, nil
+}
+
+func (d *Driver) DeleteWindow() error {
+	return d.Session.Execute("window", "DELETE", nil, &struct{}{})
+}
+
+// SetFrame switches to the provided frame element, or to the top-level
+// browsing context if frame is nil.
+func (d *Driver) SetFrame(frame types.Element) error {
+	request := struct {
+		ID interface{} `json:"id"`
+	}{}
+
+	if frame != nil {
+		request.ID = map[string]string{"ELEMENT": frame.GetID()}
+	}
+
+	return d.Session.Execute("frame", "POST", request, &struct{}{})
+}
+
+func (d *Driver) SetParentFrame() error {
+	return d.Session.Execute("frame/parent", "POST", struct{}{}, &struct{}{})
+}
+
+func (d *Driver) GetAlertText() (string, error) {
+	var text string
+	if err := d.Session.Execute("alert_text", "GET", nil, &text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+func (d *Driver) SetAlertText(text string) error {
+	request := struct {
+		Text string `json:"text"`
+	}{text}
+
+	return d.Session.Execute("alert_text", "POST", request, &struct{}{})
+}
+
+func (d *Driver) AcceptAlert() error {
+	return d.Session.Execute("accept_alert", "POST", struct{}{}, &struct{}{})
+}
+
+func (d *Driver) DismissAlert() error {
+	return d.Session.Execute("dismiss_alert", "POST", struct{}{}, &struct{}{})
+}
+
+func (d *Driver) GetCookies() ([]*types.Cookie, error) {
+	var cookies []*types.Cookie
+	if err := d.Session.Execute("cookie", "GET", nil, &cookies); err != nil {
+		return nil, err
+	}
+	return cookies, nil
+}
+
 func (d *Driver) SetCookie(cookie *types.Cookie) error {
 	request := struct {
 		Cookie *types.Cookie `json:"cookie"`
@@ -54,6 +176,14 @@ func (d *Driver) DeleteCookies() error {
 	return d.Session.Execute("cookie", "DELETE", nil, &struct{}{})
 }
 
+func (d *Driver) GetLocalStorage() types.Storage {
+	return &storage.Storage{Name: "local_storage", Session: d.Session}
+}
+
+func (d *Driver) GetSessionStorage() types.Storage {
+	return &storage.Storage{Name: "session_storage", Session: d.Session}
+}
+
 func (d *Driver) GetScreenshot() ([]byte, error) {
 	var base64Image string
 
@@ -99,6 +229,39 @@ func (d *Driver) GetSource() (string, error) {
 	return source, nil
 }
 
+func (d *Driver) GetLogs(logType string) ([]types.Log, error) {
+	request := struct {
+		Type string `json:"type"`
+	}{logType}
+
+	var entries []struct {
+		Timestamp int64  `json:"timestamp"`
+		Level     string `json:"level"`
+		Message   string `json:"message"`
+	}
+	if err := d.Session.Execute("log", "POST", request, &entries); err != nil {
+		return nil, err
+	}
+
+	logs := make([]types.Log, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, types.Log{
+			Message: entry.Message,
+			Level:   entry.Level,
+			Time:    time.Unix(0, entry.Timestamp*int64(time.Millisecond)),
+		})
+	}
+	return logs, nil
+}
+
+func (d *Driver) GetLogTypes() ([]string, error) {
+	var logTypes []string
+	if err := d.Session.Execute("log/types", "GET", nil, &logTypes); err != nil {
+		return nil, err
+	}
+	return logTypes, nil
+}
+
 func (d *Driver) DoubleClick() error {
 	return d.Session.Execute("doubleclick", "POST", nil, &struct{}{})
 }
@@ -123,11 +286,18 @@ func (d *Driver) MoveTo(element types.Element, point types.Point) error {
 	return d.Session.Execute("moveto", "POST", request, &struct{}{})
 }
 
+// Execute runs the script body with the provided arguments, where any
+// types.Element or []types.Element arguments are passed as DOM elements.
 func (d *Driver) Execute(body string, arguments []interface{}, result interface{}) error {
+	args := []interface{}{}
+	for _, argument := range arguments {
+		args = append(args, elementReference(argument))
+	}
+
 	request := struct {
 		Script string        `json:"script"`
 		Args   []interface{} `json:"args"`
-	}{body, arguments}
+	}{body, args}
 
 	if err := d.Session.Execute("execute", "POST", request, result); err != nil {
 		return err
@@ -136,6 +306,71 @@ func (d *Driver) Execute(body string, arguments []interface{}, result interface{
 	return nil
 }
 
+// ExecuteAsync runs the script body with the provided arguments, followed by
+// a callback argument that the script must call to provide its result.
+func (d *Driver) ExecuteAsync(body string, arguments []interface{}, result interface{}) error {
+	args := []interface{}{}
+	for _, argument := range arguments {
+		args = append(args, elementReference(argument))
+	}
+
+	request := struct {
+		Script string        `json:"script"`
+		Args   []interface{} `json:"args"`
+	}{body, args}
+
+	return d.Session.Execute("execute_async", "POST", request, result)
+}
+
+// SetTimeout sets the JSON Wire timeout of the provided type, such as "script",
+// "implicit" or "page load".
+func (d *Driver) SetTimeout(timeoutType string, timeout time.Duration) error {
+	request := struct {
+		Type string `json:"type"`
+		MS   int64  `json:"ms"`
+	}{timeoutType, int64(timeout / time.Millisecond)}
+
+	return d.Session.Execute("timeouts", "POST", request, &struct{}{})
+}
+
+// GetTimeout returns the timeout of the provided type, such as "script",
+// "implicit" or "page load". Only W3C WebDrivers report their timeouts.
+// A zero timeout is returned when the WebDriver reports no timeout.
+func (d *Driver) GetTimeout(timeoutType string) (time.Duration, error) {
+	var timeouts map[string]*int64
+	if err := d.Session.Execute("timeouts", "GET", nil, &timeouts); err != nil {
+		return 0, err
+	}
+
+	key := timeoutType
+	if key == "page load" {
+		key = "pageLoad"
+	}
+
+	timeout, ok := timeouts[key]
+	if !ok {
+		return 0, fmt.Errorf("%s timeout not reported", timeoutType)
+	}
+	if timeout == nil {
+		return 0, nil
+	}
+	return time.Duration(*timeout) * time.Millisecond, nil
+}
+
+func elementReference(argument interface{}) interface{} {
+	switch typed := argument.(type) {
+	case types.Element:
+		return map[string]string{"ELEMENT": typed.GetID()}
+	case []types.Element:
+		references := []interface{}{}
+		for _, element := range typed {
+			references = append(references, elementReference(element))
+		}
+		return references
+	}
+	return argument
+}
+
 func (d *Driver) Forward() error {
 	return d.Session.Execute("forward", "POST", nil, &struct{}{})
 }
@@ -1,6 +1,7 @@
 package webdriver_test
 
 import (
+	"context"
 	"errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -9,6 +10,8 @@ import (
 	. "github.com/sclevine/agouti/core/internal/webdriver"
 	"github.com/sclevine/agouti/core/internal/webdriver/element"
 	"github.com/sclevine/agouti/core/internal/webdriver/window"
+	"net/http"
+	"time"
 )
 
 var _ = Describe("Webdriver", func() {
@@ -23,6 +26,15 @@ var _ = Describe("Webdriver", func() {
 		driver = &Driver{session}
 	})
 
+	Describe("#GetCapabilities", func() {
+		Context("when the session was not created with negotiated capabilities", func() {
+			It("returns an error", func() {
+				_, err = driver.GetCapabilities()
+				Expect(err).To(MatchError("session was not created with negotiated capabilities"))
+			})
+		})
+	})
+
 	Describe("#GetElements", func() {
 		var elements []types.Element
 
@@ -101,13 +113,264 @@ var _ = Describe("Webdriver", func() {
 		})
 	})
 
+	Describe("#GetWindows", func() {
+		var windows []types.Window
+
+		BeforeEach(func() {
+			session.ExecuteCall.Result = `["some-id", "some-other-id"]`
+			windows, err = driver.GetWindows()
+		})
+
+		It("makes a GET request", func() {
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+		})
+
+		It("hits the /window_handles endpoint", func() {
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window_handles"))
+		})
+
+		Context("when the session indicates a success", func() {
+			It("returns the windows with the retrieved IDs and session", func() {
+				Expect(windows[0].(*window.Window).ID).To(Equal("some-id"))
+				Expect(windows[1].(*window.Window).ID).To(Equal("some-other-id"))
+				Expect(windows[1].(*window.Window).Session).To(Equal(session))
+			})
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.GetWindows()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetWindow", func() {
+		BeforeEach(func() {
+			err = driver.SetWindow(&window.Window{ID: "some-id"})
+		})
+
+		It("makes a POST request", func() {
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+		})
+
+		It("hits the /window endpoint", func() {
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window"))
+		})
+
+		It("includes the window ID in the request body for both dialects", func() {
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"name": "some-id", "handle": "some-id"}`))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(driver.SetWindow(&window.Window{ID: "some-id"})).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#NewWindow", func() {
+		var newWindow types.Window
+
+		BeforeEach(func() {
+			session.ExecuteCall.Result = `{"handle": "some-id", "type": "window"}`
+			newWindow, err = driver.NewWindow()
+		})
+
+		It("makes a POST request to the /window/new endpoint", func() {
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window/new"))
+		})
+
+		It("requests a window rather than a tab", func() {
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"type": "window"}`))
+		})
+
+		Context("when the session indicates a success", func() {
+			It("returns the new window with the retrieved handle and session", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newWindow.(*window.Window).ID).To(Equal("some-id"))
+				Expect(newWindow.(*window.Window).Session).To(Equal(session))
+			})
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.NewWindow()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#DeleteWindow", func() {
+		It("makes a DELETE request to the /window endpoint", func() {
+			Expect(driver.DeleteWindow()).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("DELETE"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window"))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(driver.DeleteWindow()).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetFrame", func() {
+		It("makes a POST request to the /frame endpoint", func() {
+			Expect(driver.SetFrame(&element.Element{ID: "some-id"})).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("frame"))
+		})
+
+		It("includes the frame element in the request body", func() {
+			driver.SetFrame(&element.Element{ID: "some-id"})
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"id": {"ELEMENT": "some-id"}}`))
+		})
+
+		Context("when no frame element is provided", func() {
+			It("requests the top-level browsing context", func() {
+				driver.SetFrame(nil)
+				Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"id": null}`))
+			})
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(driver.SetFrame(nil)).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetParentFrame", func() {
+		It("makes a POST request to the /frame/parent endpoint", func() {
+			Expect(driver.SetParentFrame()).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("frame/parent"))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(driver.SetParentFrame()).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetAlertText", func() {
+		It("makes a GET request to the /alert_text endpoint", func() {
+			session.ExecuteCall.Result = `"some text"`
+			Expect(driver.GetAlertText()).To(Equal("some text"))
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("alert_text"))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.GetAlertText()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetAlertText", func() {
+		It("makes a POST request to the /alert_text endpoint with the text", func() {
+			Expect(driver.SetAlertText("some text")).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("alert_text"))
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"text": "some text"}`))
+		})
+	})
+
+	Describe("#AcceptAlert", func() {
+		It("makes a POST request to the /accept_alert endpoint", func() {
+			Expect(driver.AcceptAlert()).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("accept_alert"))
+		})
+	})
+
+	Describe("#DismissAlert", func() {
+		It("makes a POST request to the /dismiss_alert endpoint", func() {
+			Expect(driver.DismissAlert()).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("dismiss_alert"))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(driver.DismissAlert()).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetCookies", func() {
+		It("makes a GET request to the /cookie endpoint", func() {
+			driver.GetCookies()
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("cookie"))
+		})
+
+		It("returns the cookies with their HTTP equivalents", func() {
+			session.ExecuteCall.Result = `[{"name": "some-name", "value": "some-value", "domain": "example.com", "expiry": 1412358590, "sameSite": "Strict"}]`
+			cookies, err := driver.GetCookies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cookies[0].HTTPCookie()).To(Equal(&http.Cookie{
+				Name:     "some-name",
+				Value:    "some-value",
+				Domain:   "example.com",
+				Expires:  time.Unix(1412358590, 0),
+				SameSite: http.SameSiteStrictMode,
+			}))
+		})
+
+		It("truncates a fractional expiry to whole seconds", func() {
+			session.ExecuteCall.Result = `[{"name": "some-name", "value": "some-value", "expiry": 1412358590.75}]`
+			cookies, err := driver.GetCookies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cookies[0].Expiry).To(Equal(int64(1412358590)))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.GetCookies()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetLocalStorage", func() {
+		It("returns the localStorage for the session", func() {
+			storage := driver.GetLocalStorage()
+			session.ExecuteCall.Result = `["some-key"]`
+			Expect(storage.Keys()).To(Equal([]string{"some-key"}))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("local_storage"))
+		})
+	})
+
+	Describe("#GetSessionStorage", func() {
+		It("returns the sessionStorage for the session", func() {
+			storage := driver.GetSessionStorage()
+			storage.Clear()
+			Expect(session.ExecuteCall.Endpoint).To(Equal("session_storage"))
+		})
+	})
+
 	Describe("#SetCookie", func() {
 		var cookie *types.Cookie
 
 		BeforeEach(func() {
 			cookie = &types.Cookie{
 				Name:     "some-name",
-				Value:    42,
+				Value:    "42",
 				Path:     "/my-path",
 				Domain:   "example.com",
 				Secure:   false,
@@ -127,7 +390,7 @@ var _ = Describe("Webdriver", func() {
 		})
 
 		It("includes the cookie to add in the request body", func() {
-			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"cookie":{"name":"some-name","value":42,"path":"/my-path","domain":"example.com","secure":false,"httpOnly":false,"expiry":1412358590}}`))
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"cookie":{"name":"some-name","value":"42","path":"/my-path","domain":"example.com","secure":false,"httpOnly":false,"expiry":1412358590}}`))
 		})
 
 		Context("when the sesssion indicates a success", func() {
@@ -136,6 +399,14 @@ var _ = Describe("Webdriver", func() {
 			})
 		})
 
+		Context("when the cookie is a session cookie", func() {
+			It("omits the expiry from the request body", func() {
+				cookie.Expiry = 0
+				Expect(driver.SetCookie(cookie)).To(Succeed())
+				Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"cookie":{"name":"some-name","value":"42","path":"/my-path","domain":"example.com","secure":false,"httpOnly":false}}`))
+			})
+		})
+
 		Context("when the session indicates a failure", func() {
 			It("returns an error indicating the page failed to add the cookie", func() {
 				session.ExecuteCall.Err = errors.New("some error")
@@ -392,6 +663,78 @@ var _ = Describe("Webdriver", func() {
 		})
 	})
 
+	Describe("#GetLogs", func() {
+		var logs []types.Log
+
+		BeforeEach(func() {
+			session.ExecuteCall.Result = `[{"timestamp": 1412358590123, "level": "WARNING", "message": "some message"}]`
+			logs, err = driver.GetLogs("browser")
+		})
+
+		It("makes a POST request", func() {
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+		})
+
+		It("hits the /log endpoint", func() {
+			Expect(session.ExecuteCall.Endpoint).To(Equal("log"))
+		})
+
+		It("includes the log type", func() {
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"type": "browser"}`))
+		})
+
+		Context("when the session indicates a success", func() {
+			It("returns the log entries", func() {
+				Expect(logs).To(HaveLen(1))
+				Expect(logs[0].Message).To(Equal("some message"))
+				Expect(logs[0].Level).To(Equal("WARNING"))
+				Expect(logs[0].Time).To(Equal(time.Unix(1412358590, 123000000)))
+			})
+
+			It("doesn't return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns the error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.GetLogs("browser")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetLogTypes", func() {
+		var logTypes []string
+
+		BeforeEach(func() {
+			session.ExecuteCall.Result = `["browser", "driver"]`
+			logTypes, err = driver.GetLogTypes()
+		})
+
+		It("makes a GET request", func() {
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+		})
+
+		It("hits the /log/types endpoint", func() {
+			Expect(session.ExecuteCall.Endpoint).To(Equal("log/types"))
+		})
+
+		It("returns the log types", func() {
+			Expect(logTypes).To(Equal([]string{"browser", "driver"}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns the error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.GetLogTypes()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#DoubleClick", func() {
 		BeforeEach(func() {
 			err = driver.DoubleClick()
@@ -505,6 +848,16 @@ var _ = Describe("Webdriver", func() {
 			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, "two"]}`))
 		})
 
+		It("passes element arguments as element references", func() {
+			element := &mocks.Element{}
+			element.GetIDCall.ReturnID = "some-id"
+			driver.Execute("some javascript code", []interface{}{element, []types.Element{element}}, &result)
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{
+				"script": "some javascript code",
+				"args": [{"ELEMENT": "some-id"}, [{"ELEMENT": "some-id"}]]
+			}`))
+		})
+
 		Context("when the session indicates a success", func() {
 			It("fills the provided results interface", func() {
 				Expect(result.Some).To(Equal("result"))
@@ -524,6 +877,73 @@ var _ = Describe("Webdriver", func() {
 		})
 	})
 
+	Describe("#ExecuteAsync", func() {
+		It("makes a POST request to the /execute_async endpoint with the script and arguments", func() {
+			var result string
+			session.ExecuteCall.Result = `"some result"`
+			element := &mocks.Element{}
+			element.GetIDCall.ReturnID = "some-id"
+			Expect(driver.ExecuteAsync("some javascript code", []interface{}{1, element}, &result)).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("execute_async"))
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, {"ELEMENT": "some-id"}]}`))
+			Expect(result).To(Equal("some result"))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(driver.ExecuteAsync("", nil, &struct{}{})).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetTimeout", func() {
+		It("makes a POST request to the /timeouts endpoint with the type and milliseconds", func() {
+			Expect(driver.SetTimeout("script", 2*time.Second)).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("timeouts"))
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"type": "script", "ms": 2000}`))
+		})
+	})
+
+	Describe("#GetTimeout", func() {
+		BeforeEach(func() {
+			session.ExecuteCall.Result = `{"script": null, "pageLoad": 300000, "implicit": 0}`
+		})
+
+		It("makes a GET request to the /timeouts endpoint", func() {
+			driver.GetTimeout("implicit")
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("timeouts"))
+		})
+
+		It("returns the timeout of the provided type", func() {
+			Expect(driver.GetTimeout("page load")).To(Equal(300 * time.Second))
+		})
+
+		Context("when the WebDriver reports no timeout", func() {
+			It("returns a zero timeout", func() {
+				Expect(driver.GetTimeout("script")).To(BeZero())
+			})
+		})
+
+		Context("when the WebDriver does not report the timeout", func() {
+			It("returns an error", func() {
+				_, err = driver.GetTimeout("some")
+				Expect(err).To(MatchError("some timeout not reported"))
+			})
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns the error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err = driver.GetTimeout("script")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#Forward", func() {
 		BeforeEach(func() {
 			err = driver.Forward()
@@ -608,4 +1028,36 @@ var _ = Describe("Webdriver", func() {
 		})
 	})
 
+	Describe("#WithContext", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.WithValue(context.Background(), "some-key", "some-value")
+		})
+
+		It("returns a driver that makes requests using the provided context", func() {
+			driver.WithContext(ctx).GetURL()
+			Expect(session.ExecuteCall.Ctx).To(Equal(ctx))
+		})
+
+		It("returns elements that make requests using the provided context", func() {
+			session.ExecuteCall.Result = `[{"ELEMENT": "some-id"}]`
+			elements, _ := driver.WithContext(ctx).GetElements(types.Selector{Using: "css selector", Value: "#selector"})
+			session.ExecuteCall.Ctx = nil
+			elements[0].Click()
+			Expect(session.ExecuteCall.Ctx).To(Equal(ctx))
+		})
+
+		It("replaces any previously provided context", func() {
+			otherCtx := context.WithValue(context.Background(), "some-key", "some-other-value")
+			driver.WithContext(otherCtx).WithContext(ctx).GetURL()
+			Expect(session.ExecuteCall.Ctx).To(Equal(ctx))
+		})
+
+		It("does not modify the original driver", func() {
+			driver.WithContext(ctx)
+			driver.GetURL()
+			Expect(session.ExecuteCall.Ctx).To(BeNil())
+		})
+	})
 })
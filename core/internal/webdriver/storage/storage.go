@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/core/internal/types"
+	"net/url"
+	"strings"
+)
+
+// Scripts are equivalent to the JSON Wire storage endpoints, by request method,
+// for WebDrivers that do not provide them. The first argument is the name of
+// the storage object, such as "localStorage", and a POST takes the key and value.
+var Scripts = map[string]string{
+	"GET": `var storage = window[arguments[0]], keys = [];
+for (var i = 0; i < storage.length; i++) keys.push(storage.key(i));
+return keys;`,
+	"POST":   `window[arguments[0]].setItem(arguments[1], arguments[2]);`,
+	"DELETE": `window[arguments[0]].clear();`,
+}
+
+// KeyScripts are equivalent to the JSON Wire storage key endpoints, by request
+// method. The arguments are the name of the storage object and the key.
+var KeyScripts = map[string]string{
+	"GET":    `return window[arguments[0]].getItem(arguments[1]);`,
+	"DELETE": `window[arguments[0]].removeItem(arguments[1]);`,
+}
+
+type Executable interface {
+	Execute(endpoint, method string, body, result interface{}) error
+}
+
+// Storage uses the JSON Wire storage endpoints, where Name is either
+// "local_storage" or "session_storage". When the WebDriver does not provide
+// them, the equivalent scripts are run instead.
+type Storage struct {
+	Name    string
+	Session Executable
+}
+
+func (s *Storage) Get(key string) (string, error) {
+	var value *string
+	if err := s.execute(s.keyEndpoint(key), "GET", nil, KeyScripts["GET"], []interface{}{key}, &value); err != nil {
+		return "", err
+	}
+
+	if value == nil {
+		return "", nil
+	}
+	return *value, nil
+}
+
+func (s *Storage) Set(key, value string) error {
+	request := struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}{key, value}
+
+	return s.execute(s.Name, "POST", request, Scripts["POST"], []interface{}{key, value}, &struct{}{})
+}
+
+func (s *Storage) Delete(key string) error {
+	return s.execute(s.keyEndpoint(key), "DELETE", nil, KeyScripts["DELETE"], []interface{}{key}, &struct{}{})
+}
+
+func (s *Storage) Keys() ([]string, error) {
+	var keys []string
+	if err := s.execute(s.Name, "GET", nil, Scripts["GET"], nil, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *Storage) Clear() error {
+	return s.execute(s.Name, "DELETE", nil, Scripts["DELETE"], nil, &struct{}{})
+}
+
+// execute makes a request to a storage endpoint, and runs the provided script
+// with the name of the storage object and the provided arguments when the
+// WebDriver does not provide the endpoint.
+func (s *Storage) execute(endpoint, method string, body interface{}, script string, arguments []interface{}, result interface{}) error {
+	err := s.Session.Execute(endpoint, method, body, result)
+	if !errors.Is(err, types.ErrUnknownCommand) {
+		return err
+	}
+
+	request := struct {
+		Script string        `json:"script"`
+		Args   []interface{} `json:"args"`
+	}{script, append([]interface{}{s.objectName()}, arguments...)}
+
+	return s.Session.Execute("execute", "POST", request, result)
+}
+
+func (s *Storage) keyEndpoint(key string) string {
+	return s.Name + "/key/" + url.PathEscape(key)
+}
+
+// objectName returns the name of the storage object in the browser, such as "localStorage".
+func (s *Storage) objectName() string {
+	return strings.TrimSuffix(s.Name, "_storage") + "Storage"
+}
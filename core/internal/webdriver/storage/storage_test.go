@@ -0,0 +1,144 @@
+package storage_test
+
+import (
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core/internal/mocks"
+	"github.com/sclevine/agouti/core/internal/types"
+	. "github.com/sclevine/agouti/core/internal/webdriver/storage"
+)
+
+// scriptSession only supports the execute endpoint, like a WebDriver without storage endpoints.
+type scriptSession struct {
+	endpoints []string
+	bodyJSON  []byte
+	result    string
+}
+
+func (s *scriptSession) Execute(endpoint, method string, body, result interface{}) error {
+	s.endpoints = append(s.endpoints, endpoint)
+	if endpoint != "execute" {
+		return types.ErrUnknownCommand
+	}
+	s.bodyJSON, _ = json.Marshal(body)
+	return json.Unmarshal([]byte(s.result), result)
+}
+
+var _ = Describe("Storage", func() {
+	var (
+		storage *Storage
+		session *mocks.Session
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		storage = &Storage{"local_storage", session}
+	})
+
+	Describe("#Get", func() {
+		It("makes a GET request to the /local_storage/key/:key endpoint", func() {
+			session.ExecuteCall.Result = `"some value"`
+			Expect(storage.Get("some/key")).To(Equal("some value"))
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("local_storage/key/some%2Fkey"))
+		})
+
+		Context("when the key is not stored", func() {
+			It("returns an empty value", func() {
+				session.ExecuteCall.Result = `null`
+				Expect(storage.Get("some-key")).To(BeEmpty())
+			})
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := storage.Get("some-key")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Set", func() {
+		It("makes a POST request to the /local_storage endpoint with the key and value", func() {
+			Expect(storage.Set("some-key", "some value")).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("local_storage"))
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"key": "some-key", "value": "some value"}`))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(storage.Set("some-key", "some value")).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Delete", func() {
+		It("makes a DELETE request to the /local_storage/key/:key endpoint", func() {
+			Expect(storage.Delete("some-key")).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("DELETE"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("local_storage/key/some-key"))
+		})
+	})
+
+	Describe("#Keys", func() {
+		It("makes a GET request to the /local_storage endpoint", func() {
+			session.ExecuteCall.Result = `["some-key", "some-other-key"]`
+			Expect(storage.Keys()).To(Equal([]string{"some-key", "some-other-key"}))
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("local_storage"))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := storage.Keys()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Clear", func() {
+		It("makes a DELETE request to the /local_storage endpoint", func() {
+			Expect(storage.Clear()).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("DELETE"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("local_storage"))
+		})
+	})
+
+	Context("when the WebDriver does not provide the storage endpoints", func() {
+		var fallbackSession *scriptSession
+
+		BeforeEach(func() {
+			fallbackSession = &scriptSession{result: "null"}
+			storage = &Storage{"session_storage", fallbackSession}
+		})
+
+		It("retrieves a value by running a script", func() {
+			fallbackSession.result = `"some value"`
+			Expect(storage.Get("some-key")).To(Equal("some value"))
+			Expect(fallbackSession.endpoints).To(Equal([]string{"session_storage/key/some-key", "execute"}))
+			Expect(fallbackSession.bodyJSON).To(MatchJSON(`{"script": "return window[arguments[0]].getItem(arguments[1]);", "args": ["sessionStorage", "some-key"]}`))
+		})
+
+		It("sets a value by running a script", func() {
+			Expect(storage.Set("some-key", "some value")).To(Succeed())
+			Expect(fallbackSession.bodyJSON).To(MatchJSON(`{"script": "window[arguments[0]].setItem(arguments[1], arguments[2]);", "args": ["sessionStorage", "some-key", "some value"]}`))
+		})
+
+		It("retrieves the keys by running a script", func() {
+			fallbackSession.result = `["some-key"]`
+			Expect(storage.Keys()).To(Equal([]string{"some-key"}))
+			Expect(fallbackSession.endpoints).To(Equal([]string{"session_storage", "execute"}))
+		})
+
+		It("clears the storage by running a script", func() {
+			Expect(storage.Clear()).To(Succeed())
+			Expect(fallbackSession.bodyJSON).To(MatchJSON(`{"script": "window[arguments[0]].clear();", "args": ["sessionStorage"]}`))
+		})
+	})
+})
@@ -9,15 +9,67 @@ type Window struct {
 	Session Executable
 }
 
+func (w *Window) GetID() string {
+	return w.ID
+}
+
+func (w *Window) GetSize() (width, height int, err error) {
+	var size struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}
+
+	if err := w.Session.Execute(w.endpoint("size"), "GET", nil, &size); err != nil {
+		return 0, 0, err
+	}
+	return size.Width, size.Height, nil
+}
+
 func (w *Window) SetSize(width, height int) error {
-	endpoint := "window/" + w.ID + "/size"
 	request := struct {
 		Width  int `json:"width"`
 		Height int `json:"height"`
 	}{width, height}
 
-	if err := w.Session.Execute(endpoint, "POST", &request, &struct{}{}); err != nil {
+	if err := w.Session.Execute(w.endpoint("size"), "POST", &request, &struct{}{}); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (w *Window) GetPosition() (x, y int, err error) {
+	var position struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	if err := w.Session.Execute(w.endpoint("position"), "GET", nil, &position); err != nil {
+		return 0, 0, err
+	}
+	return position.X, position.Y, nil
+}
+
+func (w *Window) SetPosition(x, y int) error {
+	request := struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}{x, y}
+
+	return w.Session.Execute(w.endpoint("position"), "POST", &request, &struct{}{})
+}
+
+func (w *Window) Maximize() error {
+	return w.Session.Execute(w.endpoint("maximize"), "POST", struct{}{}, &struct{}{})
+}
+
+func (w *Window) Minimize() error {
+	return w.Session.Execute(w.endpoint("minimize"), "POST", struct{}{}, &struct{}{})
+}
+
+func (w *Window) Fullscreen() error {
+	return w.Session.Execute(w.endpoint("fullscreen"), "POST", struct{}{}, &struct{}{})
+}
+
+func (w *Window) endpoint(action string) string {
+	return "window/" + w.ID + "/" + action
+}
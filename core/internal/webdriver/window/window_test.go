@@ -51,4 +51,86 @@ var _ = Describe("Window", func() {
 			})
 		})
 	})
+
+	Describe("#GetSize", func() {
+		It("makes a GET request to the /window/:id/size endpoint", func() {
+			window.GetSize()
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window/some-id/size"))
+		})
+
+		It("returns the width and height of the window", func() {
+			session.ExecuteCall.Result = `{"width": 640, "height": 480}`
+			width, height, err := window.GetSize()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(width).To(Equal(640))
+			Expect(height).To(Equal(480))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, _, err = window.GetSize()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetPosition", func() {
+		It("makes a GET request to the /window/:id/position endpoint", func() {
+			window.GetPosition()
+			Expect(session.ExecuteCall.Method).To(Equal("GET"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window/some-id/position"))
+		})
+
+		It("returns the position of the window", func() {
+			session.ExecuteCall.Result = `{"x": 10, "y": 20}`
+			x, y, err := window.GetPosition()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(x).To(Equal(10))
+			Expect(y).To(Equal(20))
+		})
+
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, _, err = window.GetPosition()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetPosition", func() {
+		It("makes a POST request to the /window/:id/position endpoint with the position", func() {
+			Expect(window.SetPosition(10, 20)).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window/some-id/position"))
+			Expect(session.ExecuteCall.BodyJSON).To(MatchJSON(`{"x": 10, "y": 20}`))
+		})
+	})
+
+	Describe("#Maximize", func() {
+		It("makes a POST request to the /window/:id/maximize endpoint", func() {
+			Expect(window.Maximize()).To(Succeed())
+			Expect(session.ExecuteCall.Method).To(Equal("POST"))
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window/some-id/maximize"))
+		})
+	})
+
+	Describe("#Minimize", func() {
+		It("makes a POST request to the /window/:id/minimize endpoint", func() {
+			Expect(window.Minimize()).To(Succeed())
+			Expect(session.ExecuteCall.Endpoint).To(Equal("window/some-id/minimize"))
+		})
+	})
+
+	Describe("#Fullscreen", func() {
+		Context("when the session indicates a failure", func() {
+			It("returns an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(window.Fullscreen()).To(MatchError("some error"))
+				Expect(session.ExecuteCall.Endpoint).To(Equal("window/some-id/fullscreen"))
+			})
+		})
+	})
 })
@@ -0,0 +1,7 @@
+package core
+
+import "github.com/sclevine/agouti/core/internal/types"
+
+// Log is an entry returned by Page.ReadLogs, with the level (such as "INFO",
+// "WARNING" or "SEVERE"), the time it was logged and its message.
+type Log = types.Log
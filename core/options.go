@@ -0,0 +1,242 @@
+package core
+
+import (
+	"crypto/tls"
+	"github.com/sclevine/agouti/core/internal/browser"
+	"github.com/sclevine/agouti/core/internal/service"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Browser or an attached Page.
+type Option func(*config)
+
+type config struct {
+	header        http.Header
+	username      string
+	password      string
+	tlsConfig     *tls.Config
+	output        io.Writer
+	outputPath    string
+	verbose       bool
+	driverLogPath string
+	gracePeriod   time.Duration
+	maxRestarts   int
+	ports         service.Ports
+	binary        string
+	args          []string
+	env           []string
+	dir           string
+	startTimeout  time.Duration
+	pollInterval  time.Duration
+	urlPrefix     string
+	loggingPrefs  map[string]string
+	pageTimeouts  browser.Timeouts
+	timeout       time.Duration
+}
+
+func newConfig(options []Option) *config {
+	c := &config{header: http.Header{}, startTimeout: 5 * time.Second}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// BasicAuth authenticates every request to the WebDriver with the provided credentials.
+func BasicAuth(username, password string) Option {
+	return func(c *config) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// Header adds a header to every request made to the WebDriver.
+func Header(key, value string) Option {
+	return func(c *config) {
+		c.header.Add(key, value)
+	}
+}
+
+// TLSConfig configures the TLS client used to connect to an HTTPS WebDriver endpoint.
+func TLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *config) {
+		c.tlsConfig = tlsConfig
+	}
+}
+
+// Output writes the standard output and standard error of the WebDriver process to w.
+func Output(w io.Writer) Option {
+	return func(c *config) {
+		c.output = w
+	}
+}
+
+// OutputFile appends the standard output and standard error of the WebDriver process to the file at path.
+func OutputFile(path string) Option {
+	return func(c *config) {
+		c.outputPath = path
+	}
+}
+
+// Verbose enables the most detailed logging supported by the WebDriver process.
+func Verbose() Option {
+	return func(c *config) {
+		c.verbose = true
+	}
+}
+
+// DriverLog directs the WebDriver process to write its own log to the file at path.
+func DriverLog(path string) Option {
+	return func(c *config) {
+		c.driverLogPath = path
+	}
+}
+
+// GracePeriod sets how long Stop waits for the WebDriver process to exit after
+// interrupting it before killing it along with any browsers it launched.
+func GracePeriod(period time.Duration) Option {
+	return func(c *config) {
+		c.gracePeriod = period
+	}
+}
+
+// RestartOnExit restarts a WebDriver process that exits unexpectedly, up to
+// maxRestarts times, the next time a Page is requested.
+func RestartOnExit(maxRestarts int) Option {
+	return func(c *config) {
+		c.maxRestarts = maxRestarts
+	}
+}
+
+// PortRange restricts the WebDriver process to a port between min and max, inclusive.
+// Give each parallel test process its own range to avoid port collisions.
+func PortRange(min, max int) Option {
+	return func(c *config) {
+		c.ports = service.Ports{Min: min, Max: max}
+	}
+}
+
+// Binary runs the WebDriver executable at path instead of the default found in PATH.
+func Binary(path string) Option {
+	return func(c *config) {
+		c.binary = path
+	}
+}
+
+// Args appends extra arguments to the WebDriver command. The {{address}},
+// {{host}} and {{port}} placeholders are replaced with the address of the WebDriver.
+func Args(args ...string) Option {
+	return func(c *config) {
+		c.args = append(c.args, args...)
+	}
+}
+
+// Env adds "KEY=value" environment variables to the environment of the WebDriver process.
+func Env(env ...string) Option {
+	return func(c *config) {
+		c.env = append(c.env, env...)
+	}
+}
+
+// Dir runs the WebDriver process in the provided working directory.
+func Dir(path string) Option {
+	return func(c *config) {
+		c.dir = path
+	}
+}
+
+// StartTimeout sets how long Start waits for the WebDriver to become available. The default is 5 seconds.
+func StartTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.startTimeout = timeout
+	}
+}
+
+// PollInterval sets how often Start checks whether the WebDriver is available.
+func PollInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.pollInterval = interval
+	}
+}
+
+// URLPrefix sets the path that WebDriver requests are made under, such as "/wd/hub".
+func URLPrefix(prefix string) Option {
+	return func(c *config) {
+		c.urlPrefix = "/" + strings.Trim(prefix, "/")
+		if c.urlPrefix == "/" {
+			c.urlPrefix = ""
+		}
+	}
+}
+
+// LoggingPrefs sets the log level (such as "ALL" or "SEVERE") of each log type
+// (such as "browser" or "driver") for every Page created by Page, unless it
+// is created with PageWithCapabilities. These logs are read with Page.ReadLogs.
+func LoggingPrefs(prefs map[string]string) Option {
+	return func(c *config) {
+		c.loggingPrefs = prefs
+	}
+}
+
+// RequestTimeout bounds each request made to the WebDriver without a context
+// deadline, including the request that creates a Page. Requests may also take
+// as long as the longest script timeout, page load timeout or implicit wait of
+// the Page. The default is 60 seconds.
+func RequestTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// PageLoadTimeout sets the page load timeout of every Page created by the Browser.
+// See Page.SetPageLoadTimeout.
+func PageLoadTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.pageTimeouts.PageLoad = timeout
+	}
+}
+
+// ScriptTimeout sets the script timeout of every Page created by the Browser.
+// See Page.SetScriptTimeout.
+func ScriptTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.pageTimeouts.Script = timeout
+	}
+}
+
+// ImplicitWait sets the implicit wait of every Page created by the Browser.
+// See Page.SetImplicitWait.
+func ImplicitWait(timeout time.Duration) Option {
+	return func(c *config) {
+		c.pageTimeouts.Implicit = timeout
+	}
+}
+
+func (c *config) client() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.tlsConfig != nil {
+		transport.TLSClientConfig = c.tlsConfig
+	}
+	return &http.Client{Transport: &requestTransport{c, transport}}
+}
+
+type requestTransport struct {
+	config *config
+	base   http.RoundTripper
+}
+
+func (t *requestTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	for key, values := range t.config.header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	if t.config.username != "" {
+		request.SetBasicAuth(t.config.username, t.config.password)
+	}
+	return t.base.RoundTrip(request)
+}
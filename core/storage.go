@@ -0,0 +1,9 @@
+package core
+
+import "github.com/sclevine/agouti/core/internal/types"
+
+// Storage is the localStorage or sessionStorage of the origin of the current page,
+// as returned by Page.LocalStorage and Page.SessionStorage.
+// JSON Wire WebDrivers use their storage endpoints, while W3C WebDrivers,
+// which have no such endpoints, use scripts.
+type Storage = types.Storage
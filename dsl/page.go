@@ -3,38 +3,64 @@ package dsl
 import (
 	"fmt"
 	"github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
 	"github.com/sclevine/agouti/core"
 )
 
 var browser core.Browser
 
 // StartPhantomJS starts a PhantomJS WebDriver service for use with CreatePage.
-func StartPhantomJS() {
+func StartPhantomJS(options ...core.Option) {
 	var err error
 	checkBrowser()
-	browser, err = core.PhantomJS()
+	browser, err = core.PhantomJS(options...)
 	checkFailure(err)
 	checkFailure(browser.Start())
 }
 
 // StartChrome starts a ChromeDriver WebDriver service for use with CreatePage.
-func StartChrome() {
+func StartChrome(options ...core.Option) {
 	var err error
 	checkBrowser()
-	browser, err = core.Chrome()
+	browser, err = core.Chrome(options...)
+	checkFailure(err)
+	checkFailure(browser.Start())
+}
+
+// StartFirefox starts a geckodriver WebDriver service for use with CreatePage.
+func StartFirefox(options ...core.Option) {
+	var err error
+	checkBrowser()
+	browser, err = core.Firefox(options...)
+	checkFailure(err)
+	checkFailure(browser.Start())
+}
+
+// StartEdge starts a msedgedriver WebDriver service for use with CreatePage.
+func StartEdge(options ...core.Option) {
+	var err error
+	checkBrowser()
+	browser, err = core.Edge(options...)
 	checkFailure(err)
 	checkFailure(browser.Start())
 }
 
 // StartSelenium starts a Selenium WebDriver service for use with CreatePage.
-func StartSelenium() {
+func StartSelenium(options ...core.Option) {
 	var err error
 	checkBrowser()
-	browser, err = core.Selenium()
+	browser, err = core.Selenium(options...)
 	checkFailure(err)
 	checkFailure(browser.Start())
 }
 
+// NodePortRange gives each parallel Ginkgo node its own range of size ports,
+// starting from base for the first node, for use with the Start functions.
+func NodePortRange(base, size int) core.Option {
+	min := base + (config.GinkgoConfig.ParallelNode-1)*size
+	return core.PortRange(min, min+size-1)
+}
+
 // StopWebdriver stops the current running WebDriver.
 func StopWebdriver() {
 	if browser == nil {
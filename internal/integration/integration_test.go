@@ -48,6 +48,14 @@ var _ = Feature("Agouti running on PhantomJS", func() {
 			Expect(page.Find("header h2")).NotTo(BeVisible())
 		})
 
+		Step("reads the text and visibility of several elements as it does for each element", func() {
+			headers := page.Find("header *")
+			Expect(headers.Texts()).To(Equal([]string{"Title", ""}))
+			Expect(headers.First().Text()).To(Equal("Title"))
+			Expect(headers.Last().Text()).To(Equal(""))
+			Expect(headers.Visibilities()).To(Equal([]bool{true, false}))
+		})
+
 		Step("allows tests to be scoped by chaining", func() {
 			Expect(page.Find("header").Find("h1")).To(HaveText("Title"))
 		})
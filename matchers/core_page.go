@@ -0,0 +1,69 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/core"
+	"github.com/sclevine/agouti/matchers/internal/page"
+	"image"
+	"net/http"
+)
+
+// corePage adapts a core.Page to the page matchers, which only depend on the
+// standard library.
+type corePage struct {
+	page core.Page
+}
+
+func (p *corePage) GetCookies() ([]*http.Cookie, error) {
+	cookies, err := p.page.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+
+	httpCookies := []*http.Cookie{}
+	for _, cookie := range cookies {
+		httpCookies = append(httpCookies, cookie.HTTPCookie())
+	}
+	return httpCookies, nil
+}
+
+func (p *corePage) ScreenshotImage() (image.Image, error) {
+	return p.page.ScreenshotImage()
+}
+
+func (p *corePage) ReadLogs(logType string) ([]page.Log, error) {
+	logs, err := p.page.ReadLogs(logType)
+	if err != nil {
+		return nil, err
+	}
+
+	pageLogs := []page.Log{}
+	for _, log := range logs {
+		pageLogs = append(pageLogs, page.Log{Message: log.Message, Level: log.Level})
+	}
+	return pageLogs, nil
+}
+
+// pageMatcher passes a core.Page to matcher as a corePage, and any other value unchanged.
+type pageMatcher struct {
+	matcher types.GomegaMatcher
+}
+
+func (m *pageMatcher) Match(actual interface{}) (success bool, err error) {
+	return m.matcher.Match(adaptPage(actual))
+}
+
+func (m *pageMatcher) FailureMessage(actual interface{}) (message string) {
+	return m.matcher.FailureMessage(adaptPage(actual))
+}
+
+func (m *pageMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return m.matcher.NegatedFailureMessage(adaptPage(actual))
+}
+
+func adaptPage(actual interface{}) interface{} {
+	if actualPage, ok := actual.(core.Page); ok {
+		return &corePage{actualPage}
+	}
+	return actual
+}
@@ -0,0 +1,69 @@
+package matchers_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core"
+	. "github.com/sclevine/agouti/matchers"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Core page matchers", func() {
+	var (
+		page      core.Page
+		responses map[string]string
+		server    *httptest.Server
+	)
+
+	BeforeEach(func() {
+		responses = map[string]string{"/session/some-id/url": `"http://example.com"`}
+		server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			response.Write([]byte(`{"status": 0, "value": ` + responses[request.URL.Path] + `}`))
+		}))
+
+		var err error
+		page, err = core.AttachPage(server.URL, "some-id")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#HaveCookie", func() {
+		It("matches the cookies of a core page", func() {
+			responses["/session/some-id/cookie"] = `[{"name": "some-name", "value": "some-value"}]`
+			Expect(page).To(HaveCookie("some-name", "some-value"))
+			Expect(page).NotTo(HaveCookie("some-name", "some-other-value"))
+		})
+	})
+
+	Describe("#HaveLoggedError", func() {
+		It("matches the browser logs of a core page", func() {
+			responses["/session/some-id/log"] = `[{"level": "SEVERE", "message": "some error", "timestamp": 1412358590000}]`
+			Expect(page).To(HaveLoggedError())
+		})
+	})
+
+	Describe("#MatchScreenshot", func() {
+		It("matches a screenshot of a core page", func() {
+			directory, _ := ioutil.TempDir("", "baselines")
+			defer os.RemoveAll(directory)
+
+			screenshot := &bytes.Buffer{}
+			Expect(png.Encode(screenshot, image.NewNRGBA(image.Rect(0, 0, 10, 10)))).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(directory, "some-baseline.png"), screenshot.Bytes(), 0600)).To(Succeed())
+			responses["/session/some-id/screenshot"] = `"` + base64.StdEncoding.EncodeToString(screenshot.Bytes()) + `"`
+
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory)))
+		})
+	})
+})
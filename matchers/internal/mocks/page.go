@@ -1,12 +1,65 @@
 package mocks
 
+import (
+	"github.com/sclevine/agouti/matchers/internal/page"
+	"image"
+	"net/http"
+)
+
 type Page struct {
+	GetCookiesCall struct {
+		ReturnCookies []*http.Cookie
+		Err           error
+	}
+
 	TitleCall struct {
 		ReturnTitle string
 		Err         error
 	}
+
+	PopupTextCall struct {
+		ReturnText string
+		Err        error
+	}
+
+	WindowsCall struct {
+		ReturnWindows []string
+		Err           error
+	}
+
+	ReadLogsCall struct {
+		LogType    string
+		ReturnLogs []page.Log
+		Err        error
+	}
+
+	ScreenshotImageCall struct {
+		ReturnImage image.Image
+		Err         error
+	}
 }
 
 func (p *Page) Title() (string, error) {
 	return p.TitleCall.ReturnTitle, p.TitleCall.Err
 }
+
+func (p *Page) Windows() ([]string, error) {
+	return p.WindowsCall.ReturnWindows, p.WindowsCall.Err
+}
+
+func (p *Page) PopupText() (string, error) {
+	return p.PopupTextCall.ReturnText, p.PopupTextCall.Err
+}
+
+func (p *Page) GetCookies() ([]*http.Cookie, error) {
+	return p.GetCookiesCall.ReturnCookies, p.GetCookiesCall.Err
+}
+
+func (p *Page) ScreenshotImage() (image.Image, error) {
+	return p.ScreenshotImageCall.ReturnImage, p.ScreenshotImageCall.Err
+}
+
+func (p *Page) ReadLogs(logType string) ([]page.Log, error) {
+	p.ReadLogsCall.LogType = logType
+	return p.ReadLogsCall.ReturnLogs, p.ReadLogsCall.Err
+}
@@ -1,5 +1,7 @@
 package mocks
 
+import "image"
+
 type Selection struct {
 	StringCall struct {
 		ReturnString string
@@ -37,6 +39,11 @@ type Selection struct {
 		Err           error
 	}
 
+	TextsCall struct {
+		ReturnTexts []string
+		Err         error
+	}
+
 	CountCall struct {
 		ReturnCount int
 		Err         error
@@ -47,6 +54,17 @@ type Selection struct {
 		ReturnEquals bool
 		Err          error
 	}
+
+	ScreenshotImageCall struct {
+		ReturnImage image.Image
+		Err         error
+	}
+
+	ScreenshotBoundsCall struct {
+		Screenshot   image.Rectangle
+		ReturnBounds image.Rectangle
+		Err          error
+	}
 }
 
 func (s *Selection) String() string {
@@ -79,6 +97,10 @@ func (s *Selection) Enabled() (bool, error) {
 	return s.EnabledCall.ReturnEnabled, s.EnabledCall.Err
 }
 
+func (s *Selection) Texts() ([]string, error) {
+	return s.TextsCall.ReturnTexts, s.TextsCall.Err
+}
+
 func (s *Selection) Count() (int, error) {
 	return s.CountCall.ReturnCount, s.CountCall.Err
 }
@@ -87,3 +109,12 @@ func (s *Selection) EqualsElement(selection interface{}) (bool, error) {
 	s.EqualsElementCall.Selection = selection
 	return s.EqualsElementCall.ReturnEquals, s.EqualsElementCall.Err
 }
+
+func (s *Selection) ScreenshotImage() (image.Image, error) {
+	return s.ScreenshotImageCall.ReturnImage, s.ScreenshotImageCall.Err
+}
+
+func (s *Selection) ScreenshotBounds(screenshot image.Rectangle) (image.Rectangle, error) {
+	s.ScreenshotBoundsCall.Screenshot = screenshot
+	return s.ScreenshotBoundsCall.ReturnBounds, s.ScreenshotBoundsCall.Err
+}
@@ -0,0 +1,23 @@
+package mocks
+
+type Storage struct {
+	GetCall struct {
+		Key         string
+		ReturnValue string
+		Err         error
+	}
+
+	KeysCall struct {
+		ReturnKeys []string
+		Err        error
+	}
+}
+
+func (s *Storage) Get(key string) (string, error) {
+	s.GetCall.Key = key
+	return s.GetCall.ReturnValue, s.GetCall.Err
+}
+
+func (s *Storage) Keys() ([]string, error) {
+	return s.KeysCall.ReturnKeys, s.KeysCall.Err
+}
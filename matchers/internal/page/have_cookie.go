@@ -0,0 +1,55 @@
+package page
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"net/http"
+	"strings"
+)
+
+type HaveCookieMatcher struct {
+	ExpectedName  string
+	ExpectedValue string
+	actualCookies []string
+}
+
+func (m *HaveCookieMatcher) Match(actual interface{}) (success bool, err error) {
+	actualPage, ok := actual.(interface {
+		GetCookies() ([]*http.Cookie, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveCookie matcher requires a Page.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	cookies, err := actualPage.GetCookies()
+	if err != nil {
+		return false, err
+	}
+
+	m.actualCookies = nil
+	for _, cookie := range cookies {
+		m.actualCookies = append(m.actualCookies, cookie.Name+"="+cookie.Value)
+		if cookie.Name == m.ExpectedName && cookie.Value == m.ExpectedValue {
+			success = true
+		}
+	}
+
+	return success, nil
+}
+
+func (m *HaveCookieMatcher) FailureMessage(_ interface{}) (message string) {
+	return pageMessage("to have cookie", m.expected(), m.actual())
+}
+
+func (m *HaveCookieMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return pageMessage("not to have cookie", m.expected(), m.actual())
+}
+
+func (m *HaveCookieMatcher) expected() string {
+	return m.ExpectedName + "=" + m.ExpectedValue
+}
+
+func (m *HaveCookieMatcher) actual() string {
+	return strings.Join(m.actualCookies, "; ")
+}
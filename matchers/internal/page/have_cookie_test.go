@@ -0,0 +1,79 @@
+package page_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/page"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveCookieMatcher", func() {
+	var (
+		matcher *HaveCookieMatcher
+		page    *mocks.Page
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		page.GetCookiesCall.ReturnCookies = []*http.Cookie{
+			{Name: "some-name", Value: "some-value"},
+			{Name: "some-other-name", Value: "some-other-value"},
+		}
+		matcher = &HaveCookieMatcher{ExpectedName: "some-name", ExpectedValue: "some-value"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			Context("when the page has a cookie with the expected name and value", func() {
+				It("returns true", func() {
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeTrue())
+				})
+			})
+
+			Context("when the page has a cookie with the expected name and another value", func() {
+				It("returns false", func() {
+					matcher.ExpectedValue = "some-other-value"
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the cookies fails", func() {
+				It("returns an error", func() {
+					page.GetCookiesCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveCookie matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			matcher.ExpectedValue = "some-missing-value"
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page to have cookie\n    some-name=some-missing-value"))
+			Expect(message).To(ContainSubstring("but found\n    some-name=some-value; some-other-name=some-other-value"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(page)
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page not to have cookie\n    some-name=some-value"))
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package page
+
+type HaveLoggedErrorMatcher struct {
+	actualLogs []Log
+}
+
+func (m *HaveLoggedErrorMatcher) Match(actual interface{}) (success bool, err error) {
+	m.actualLogs, err = readBrowserLogs(actual, "HaveLoggedError")
+	if err != nil {
+		return false, err
+	}
+
+	for _, log := range m.actualLogs {
+		if log.Level == "SEVERE" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *HaveLoggedErrorMatcher) FailureMessage(_ interface{}) (message string) {
+	return pageMessage("to have logged", "an error", logsMessage(m.actualLogs))
+}
+
+func (m *HaveLoggedErrorMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return pageMessage("not to have logged", "an error", logsMessage(m.actualLogs))
+}
@@ -0,0 +1,90 @@
+package page_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/page"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveLoggedErrorMatcher", func() {
+	var (
+		matcher *HaveLoggedErrorMatcher
+		page    *mocks.Page
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		page.ReadLogsCall.ReturnLogs = []Log{
+			{Message: "some info", Level: "INFO"},
+			{Message: "some error", Level: "SEVERE"},
+		}
+		matcher = &HaveLoggedErrorMatcher{}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			It("reads the browser log", func() {
+				matcher.Match(page)
+				Expect(page.ReadLogsCall.LogType).To(Equal("browser"))
+			})
+
+			Context("when the page has logged an error", func() {
+				It("returns true", func() {
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeTrue())
+				})
+			})
+
+			Context("when the page has not logged an error", func() {
+				It("returns false", func() {
+					page.ReadLogsCall.ReturnLogs = page.ReadLogsCall.ReturnLogs[:1]
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when reading the logs fails", func() {
+				It("returns an error", func() {
+					page.ReadLogsCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveLoggedError matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			page.ReadLogsCall.ReturnLogs = page.ReadLogsCall.ReturnLogs[:1]
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page to have logged\n    an error"))
+			Expect(message).To(ContainSubstring("but found\n    [INFO] some info"))
+		})
+
+		It("reports when nothing was logged", func() {
+			page.ReadLogsCall.ReturnLogs = nil
+			matcher.Match(page)
+			Expect(matcher.FailureMessage(page)).To(ContainSubstring("but found\n    no browser logs"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(page)
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page not to have logged\n    an error"))
+			Expect(message).To(ContainSubstring("but found\n    [INFO] some info\n    [SEVERE] some error"))
+		})
+	})
+})
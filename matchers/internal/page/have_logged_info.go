@@ -0,0 +1,31 @@
+package page
+
+import "strings"
+
+type HaveLoggedInfoMatcher struct {
+	ExpectedText string
+	actualLogs   []Log
+}
+
+func (m *HaveLoggedInfoMatcher) Match(actual interface{}) (success bool, err error) {
+	m.actualLogs, err = readBrowserLogs(actual, "HaveLoggedInfo")
+	if err != nil {
+		return false, err
+	}
+
+	for _, log := range m.actualLogs {
+		if log.Level == "INFO" && strings.Contains(log.Message, m.ExpectedText) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *HaveLoggedInfoMatcher) FailureMessage(_ interface{}) (message string) {
+	return pageMessage("to have logged info containing", m.ExpectedText, logsMessage(m.actualLogs))
+}
+
+func (m *HaveLoggedInfoMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return pageMessage("not to have logged info containing", m.ExpectedText, logsMessage(m.actualLogs))
+}
@@ -0,0 +1,78 @@
+package page_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/page"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveLoggedInfoMatcher", func() {
+	var (
+		matcher *HaveLoggedInfoMatcher
+		page    *mocks.Page
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		page.ReadLogsCall.ReturnLogs = []Log{
+			{Message: "some info message", Level: "INFO"},
+			{Message: "some error message", Level: "SEVERE"},
+		}
+		matcher = &HaveLoggedInfoMatcher{ExpectedText: "info"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			Context("when the page has logged info containing the expected text", func() {
+				It("returns true", func() {
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeTrue())
+				})
+			})
+
+			Context("when the expected text was only logged at another level", func() {
+				It("returns false", func() {
+					matcher.ExpectedText = "error"
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when reading the logs fails", func() {
+				It("returns an error", func() {
+					page.ReadLogsCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveLoggedInfo matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			matcher.ExpectedText = "missing"
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page to have logged info containing\n    missing"))
+			Expect(message).To(ContainSubstring("but found\n    [INFO] some info message\n    [SEVERE] some error message"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(page)
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page not to have logged info containing\n    info"))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/onsi/gomega/format"
+)
+
+// HaveNoPopupMatcher passes when retrieving the popup text of a page fails
+// with NoPopupErr, such as core.ErrNoSuchAlert.
+type HaveNoPopupMatcher struct {
+	NoPopupErr error
+	actualText string
+}
+
+func (m *HaveNoPopupMatcher) Match(actual interface{}) (success bool, err error) {
+	actualPage, ok := actual.(interface {
+		PopupText() (string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveNoPopup matcher requires a Page.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actualText, err = actualPage.PopupText()
+	if errors.Is(err, m.NoPopupErr) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (m *HaveNoPopupMatcher) FailureMessage(_ interface{}) (message string) {
+	return fmt.Sprintf("Expected page to have no popup\nbut found popup with text\n%s%s", format.Indent, m.actualText)
+}
+
+func (m *HaveNoPopupMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return "Expected page to have a popup\nbut found no popup"
+}
@@ -0,0 +1,75 @@
+package page_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/page"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveNoPopupMatcher", func() {
+	var (
+		matcher    *HaveNoPopupMatcher
+		page       *mocks.Page
+		noPopupErr error
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		noPopupErr = errors.New("no such alert")
+		matcher = &HaveNoPopupMatcher{NoPopupErr: noPopupErr}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			Context("when no popup is open", func() {
+				It("returns true", func() {
+					page.PopupTextCall.Err = fmt.Errorf("failed to retrieve popup text: %w", noPopupErr)
+					success, err := matcher.Match(page)
+					Expect(success).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when a popup is open", func() {
+				It("returns false", func() {
+					page.PopupTextCall.ReturnText = "some text"
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the popup text fails for another reason", func() {
+				It("returns an error", func() {
+					page.PopupTextCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveNoPopup matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message with the popup text", func() {
+			page.PopupTextCall.ReturnText = "some text"
+			matcher.Match(page)
+			Expect(matcher.FailureMessage(page)).To(Equal("Expected page to have no popup\nbut found popup with text\n    some text"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			Expect(matcher.NegatedFailureMessage(page)).To(Equal("Expected page to have a popup\nbut found no popup"))
+		})
+	})
+})
@@ -0,0 +1,38 @@
+package page
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"strconv"
+)
+
+type HaveWindowCountMatcher struct {
+	ExpectedCount int
+	actualCount   int
+}
+
+func (m *HaveWindowCountMatcher) Match(actual interface{}) (success bool, err error) {
+	actualPage, ok := actual.(interface {
+		Windows() ([]string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveWindowCount matcher requires a Page.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	windows, err := actualPage.Windows()
+	if err != nil {
+		return false, err
+	}
+	m.actualCount = len(windows)
+
+	return m.actualCount == m.ExpectedCount, nil
+}
+
+func (m *HaveWindowCountMatcher) FailureMessage(_ interface{}) (message string) {
+	return pageMessage("to have window count", strconv.Itoa(m.ExpectedCount), strconv.Itoa(m.actualCount))
+}
+
+func (m *HaveWindowCountMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return pageMessage("not to have window count", strconv.Itoa(m.ExpectedCount), strconv.Itoa(m.actualCount))
+}
@@ -0,0 +1,81 @@
+package page_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/page"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveWindowCountMatcher", func() {
+	var (
+		matcher *HaveWindowCountMatcher
+		page    *mocks.Page
+	)
+
+	BeforeEach(func() {
+		page = &mocks.Page{}
+		page.WindowsCall.ReturnWindows = []string{"some-window", "some-other-window"}
+		matcher = &HaveWindowCountMatcher{ExpectedCount: 2}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a page", func() {
+			Context("when the expected count matches the number of windows", func() {
+				It("returns true", func() {
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeTrue())
+				})
+
+				It("does not return an error", func() {
+					_, err := matcher.Match(page)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the expected count does not match the number of windows", func() {
+				It("returns false", func() {
+					page.WindowsCall.ReturnWindows = []string{"some-window"}
+					success, _ := matcher.Match(page)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the windows fails", func() {
+				It("returns an error", func() {
+					page.WindowsCall.Err = errors.New("some error")
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a page", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("HaveWindowCount matcher requires a Page.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			page.WindowsCall.ReturnWindows = []string{"some-window"}
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page to have window count\n    2"))
+			Expect(message).To(ContainSubstring("but found\n    1"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(page)
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected page not to have window count\n    2"))
+			Expect(message).To(ContainSubstring("but found\n    2"))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package page
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"strings"
+)
+
+// Log is a log entry read from a page.
+type Log struct {
+	Message string
+	Level   string
+}
+
+func readBrowserLogs(actual interface{}, matcherName string) ([]Log, error) {
+	actualPage, ok := actual.(interface {
+		ReadLogs(logType string) ([]Log, error)
+	})
+
+	if !ok {
+		return nil, fmt.Errorf("%s matcher requires a Page.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+
+	return actualPage.ReadLogs("browser")
+}
+
+func logsMessage(logs []Log) string {
+	if len(logs) == 0 {
+		return "no browser logs"
+	}
+
+	var entries []string
+	for _, log := range logs {
+		entries = append(entries, fmt.Sprintf("[%s] %s", log.Level, log.Message))
+	}
+	return strings.Join(entries, "\n"+format.Indent)
+}
@@ -0,0 +1,75 @@
+package screenshot
+
+import (
+	"image"
+	"image/color"
+)
+
+var changedColor = color.NRGBA{255, 0, 0, 255}
+
+// compare returns a diff image, in which changed pixels are red and all other
+// pixels are faded, and the percentage of compared pixels that changed.
+// Pixels within the ignored regions of actual are not compared.
+func compare(baseline, actual image.Image, ignored []image.Rectangle, tolerance uint8) (diff image.Image, diffPercent float64) {
+	bounds := actual.Bounds()
+	offset := baseline.Bounds().Min.Sub(bounds.Min)
+	diffImage := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	compared, changed := 0, 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			point := image.Pt(x, y)
+			actualColor := actual.At(x, y)
+			diffPoint := point.Sub(bounds.Min)
+
+			if !isIgnored(point, ignored) {
+				compared++
+				if !colorsMatch(baseline.At(x+offset.X, y+offset.Y), actualColor, tolerance) {
+					changed++
+					diffImage.Set(diffPoint.X, diffPoint.Y, changedColor)
+					continue
+				}
+			}
+
+			diffImage.Set(diffPoint.X, diffPoint.Y, fade(actualColor))
+		}
+	}
+
+	if compared == 0 {
+		return diffImage, 0
+	}
+	return diffImage, float64(changed) * 100 / float64(compared)
+}
+
+func isIgnored(point image.Point, ignored []image.Rectangle) bool {
+	for _, region := range ignored {
+		if point.In(region) {
+			return true
+		}
+	}
+	return false
+}
+
+func colorsMatch(expected, actual color.Color, tolerance uint8) bool {
+	expectedColor := color.NRGBAModel.Convert(expected).(color.NRGBA)
+	actualColor := color.NRGBAModel.Convert(actual).(color.NRGBA)
+
+	for _, channels := range [][2]uint8{
+		{expectedColor.R, actualColor.R},
+		{expectedColor.G, actualColor.G},
+		{expectedColor.B, actualColor.B},
+		{expectedColor.A, actualColor.A},
+	} {
+		difference := int(channels[0]) - int(channels[1])
+		if difference > int(tolerance) || -difference > int(tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// fade returns a light grey version of c, so that changed pixels stand out.
+func fade(c color.Color) color.Color {
+	gray := color.GrayModel.Convert(c).(color.Gray)
+	return color.Gray{Y: 192 + gray.Y/4}
+}
@@ -0,0 +1,169 @@
+package screenshot
+
+import (
+	"errors"
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UpdateEnv is the environment variable that, when set, causes baselines to
+// be written instead of compared.
+const UpdateEnv = "AGOUTI_UPDATE_BASELINES"
+
+// boundedSelection is a selection that reports the area its element covers
+// within a page screenshot.
+type boundedSelection interface {
+	ScreenshotBounds(screenshot image.Rectangle) (image.Rectangle, error)
+}
+
+type MatchScreenshotMatcher struct {
+	BaselineName     string
+	Directory        string
+	Update           bool
+	Tolerance        uint8
+	MaxDiffPercent   float64
+	IgnoreSelections []interface{}
+	IgnoreRegions    []image.Rectangle
+	result           string
+	written          []string
+}
+
+func (m *MatchScreenshotMatcher) Match(actual interface{}) (success bool, err error) {
+	actualImage, err := capture(actual)
+	if err != nil {
+		return false, err
+	}
+
+	if m.Update {
+		if err := writeImage(m.path(""), actualImage); err != nil {
+			return false, fmt.Errorf("failed to write baseline: %w", err)
+		}
+		return true, nil
+	}
+
+	baseline, err := readImage(m.path(""))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("baseline %s does not exist, set %s to create it", m.path(""), UpdateEnv)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	ignored, err := m.ignored(actualImage.Bounds())
+	if err != nil {
+		return false, err
+	}
+
+	baselineSize, actualSize := baseline.Bounds().Size(), actualImage.Bounds().Size()
+	if baselineSize != actualSize {
+		m.result = fmt.Sprintf("a %dx%d screenshot for a %dx%d baseline", actualSize.X, actualSize.Y, baselineSize.X, baselineSize.Y)
+		return false, m.writeFailure(actualImage, nil)
+	}
+
+	diff, diffPercent := compare(baseline, actualImage, ignored, m.Tolerance)
+	m.result = fmt.Sprintf("%.2f%% of pixels differ (at most %.2f%% may differ)", diffPercent, m.MaxDiffPercent)
+	if diffPercent <= m.MaxDiffPercent {
+		return true, nil
+	}
+
+	return false, m.writeFailure(actualImage, diff)
+}
+
+func (m *MatchScreenshotMatcher) FailureMessage(_ interface{}) (message string) {
+	message = screenshotMessage("to match baseline", m.path(""), m.result)
+	if len(m.written) > 0 {
+		message += "\nwrote " + strings.Join(m.written, " and ")
+	}
+	return message
+}
+
+func (m *MatchScreenshotMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return screenshotMessage("not to match baseline", m.path(""), m.result)
+}
+
+func (m *MatchScreenshotMatcher) path(suffix string) string {
+	return filepath.Join(m.Directory, m.BaselineName+suffix+".png")
+}
+
+// ignored returns the ignored regions in the coordinates of a screenshot with the provided bounds.
+func (m *MatchScreenshotMatcher) ignored(bounds image.Rectangle) ([]image.Rectangle, error) {
+	var regions []image.Rectangle
+	for _, region := range m.IgnoreRegions {
+		regions = append(regions, region.Add(bounds.Min))
+	}
+
+	for _, selection := range m.IgnoreSelections {
+		boundedSelection, ok := selection.(boundedSelection)
+		if !ok {
+			return nil, fmt.Errorf("MatchScreenshot cannot ignore a selection without screenshot bounds.  Got:\n%s", format.Object(selection, 1))
+		}
+
+		region, err := boundedSelection.ScreenshotBounds(bounds)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+
+	return regions, nil
+}
+
+// writeFailure writes the screenshot, and the diff if the sizes matched,
+// next to the baseline for inspection.
+func (m *MatchScreenshotMatcher) writeFailure(actualImage, diff image.Image) error {
+	m.written = nil
+	if err := writeImage(m.path(".actual"), actualImage); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	m.written = append(m.written, m.path(".actual"))
+
+	os.Remove(m.path(".diff"))
+	if diff == nil {
+		return nil
+	}
+
+	if err := writeImage(m.path(".diff"), diff); err != nil {
+		return fmt.Errorf("failed to write diff: %w", err)
+	}
+	m.written = append(m.written, m.path(".diff"))
+	return nil
+}
+
+func capture(actual interface{}) (image.Image, error) {
+	if screenshotter, ok := actual.(interface {
+		ScreenshotImage() (image.Image, error)
+	}); ok {
+		return screenshotter.ScreenshotImage()
+	}
+
+	return nil, fmt.Errorf("MatchScreenshot matcher requires a Page or Selection.  Got:\n%s", format.Object(actual, 1))
+}
+
+func readImage(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+func writeImage(path string, pngImage image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, pngImage)
+}
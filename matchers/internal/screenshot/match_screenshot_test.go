@@ -0,0 +1,236 @@
+package screenshot_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/screenshot"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MatchScreenshotMatcher", func() {
+	var (
+		matcher   *MatchScreenshotMatcher
+		page      *mocks.Page
+		directory string
+		red       = color.NRGBA{255, 0, 0, 255}
+		white     = color.NRGBA{255, 255, 255, 255}
+	)
+
+	newImage := func(bounds image.Rectangle, changed ...image.Point) *image.NRGBA {
+		newImage := image.NewNRGBA(bounds)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				newImage.Set(x, y, white)
+			}
+		}
+		for _, point := range changed {
+			newImage.Set(point.X, point.Y, red)
+		}
+		return newImage
+	}
+
+	readImage := func(path string) image.Image {
+		file, err := os.Open(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+		pngImage, err := png.Decode(file)
+		Expect(err).NotTo(HaveOccurred())
+		return pngImage
+	}
+
+	writeBaseline := func(baseline image.Image) {
+		file, _ := os.Create(filepath.Join(directory, "some-baseline.png"))
+		defer file.Close()
+		png.Encode(file, baseline)
+	}
+
+	BeforeEach(func() {
+		directory, _ = ioutil.TempDir("", "baselines")
+		page = &mocks.Page{}
+		page.ScreenshotImageCall.ReturnImage = newImage(image.Rect(0, 0, 10, 10))
+		matcher = &MatchScreenshotMatcher{BaselineName: "some-baseline", Directory: directory}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("#Match", func() {
+		Context("when updating baselines", func() {
+			It("writes the screenshot as the baseline and returns true", func() {
+				matcher.Update = true
+				matcher.BaselineName = "some-directory/some-baseline"
+				success, err := matcher.Match(page)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(success).To(BeTrue())
+				baseline := readImage(filepath.Join(directory, "some-directory", "some-baseline.png"))
+				Expect(baseline.Bounds()).To(Equal(image.Rect(0, 0, 10, 10)))
+			})
+		})
+
+		Context("when the baseline does not exist", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match(page)
+				Expect(err).To(MatchError("baseline " + filepath.Join(directory, "some-baseline.png") + " does not exist, set AGOUTI_UPDATE_BASELINES to create it"))
+			})
+		})
+
+		Context("when the screenshot matches the baseline", func() {
+			It("returns true", func() {
+				writeBaseline(newImage(image.Rect(0, 0, 10, 10)))
+				Expect(matcher.Match(page)).To(BeTrue())
+			})
+		})
+
+		Context("when the screenshot differs from the baseline", func() {
+			BeforeEach(func() {
+				writeBaseline(newImage(image.Rect(0, 0, 10, 10)))
+				page.ScreenshotImageCall.ReturnImage = newImage(image.Rect(0, 0, 10, 10), image.Pt(1, 2), image.Pt(3, 4))
+			})
+
+			It("returns false", func() {
+				Expect(matcher.Match(page)).To(BeFalse())
+			})
+
+			It("writes the screenshot and a diff highlighting the changed pixels", func() {
+				matcher.Match(page)
+				Expect(readImage(filepath.Join(directory, "some-baseline.actual.png")).Bounds()).To(Equal(image.Rect(0, 0, 10, 10)))
+				diff := readImage(filepath.Join(directory, "some-baseline.diff.png"))
+				Expect(color.NRGBAModel.Convert(diff.At(1, 2))).To(Equal(red))
+				Expect(color.NRGBAModel.Convert(diff.At(3, 4))).To(Equal(red))
+				Expect(color.NRGBAModel.Convert(diff.At(0, 0))).NotTo(Equal(red))
+			})
+
+			Context("when the changed pixels are within the pixel tolerance", func() {
+				It("returns true", func() {
+					matcher.Tolerance = 255
+					Expect(matcher.Match(page)).To(BeTrue())
+				})
+			})
+
+			Context("when the changed pixels are within the maximum diff percentage", func() {
+				It("returns true", func() {
+					matcher.MaxDiffPercent = 2
+					Expect(matcher.Match(page)).To(BeTrue())
+					matcher.MaxDiffPercent = 1.9
+					Expect(matcher.Match(page)).To(BeFalse())
+				})
+			})
+
+			Context("when the changed pixels are within ignored regions", func() {
+				It("returns true", func() {
+					matcher.IgnoreRegions = []image.Rectangle{image.Rect(1, 2, 2, 3)}
+					Expect(matcher.Match(page)).To(BeFalse())
+					matcher.IgnoreRegions = append(matcher.IgnoreRegions, image.Rect(3, 4, 4, 5))
+					Expect(matcher.Match(page)).To(BeTrue())
+				})
+			})
+
+			Context("when the changed pixels are within ignored selections", func() {
+				It("returns true", func() {
+					selection := &mocks.Selection{}
+					selection.ScreenshotBoundsCall.ReturnBounds = image.Rect(0, 0, 5, 5)
+					matcher.IgnoreSelections = []interface{}{selection}
+					Expect(matcher.Match(page)).To(BeTrue())
+					Expect(selection.ScreenshotBoundsCall.Screenshot).To(Equal(image.Rect(0, 0, 10, 10)))
+				})
+
+				It("returns an error when the selection does not report its bounds", func() {
+					matcher.IgnoreSelections = []interface{}{"not a selection"}
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError(ContainSubstring("MatchScreenshot cannot ignore a selection without screenshot bounds.  Got:")))
+				})
+
+				It("returns an error when the selection bounds cannot be retrieved", func() {
+					selection := &mocks.Selection{}
+					selection.ScreenshotBoundsCall.Err = errors.New("some error")
+					matcher.IgnoreSelections = []interface{}{selection}
+					_, err := matcher.Match(page)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the screenshot is a different size than the baseline", func() {
+			It("returns false without writing a diff", func() {
+				writeBaseline(newImage(image.Rect(0, 0, 10, 20)))
+				Expect(matcher.Match(page)).To(BeFalse())
+				_, err := os.Stat(filepath.Join(directory, "some-baseline.diff.png"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+
+		Context("when the actual object is a selection", func() {
+			var selection *mocks.Selection
+
+			BeforeEach(func() {
+				selection = &mocks.Selection{}
+				selection.ScreenshotImageCall.ReturnImage = newImage(image.Rect(0, 0, 30, 30), image.Pt(25, 25)).SubImage(image.Rect(20, 20, 30, 30))
+				writeBaseline(newImage(image.Rect(0, 0, 10, 10), image.Pt(5, 5)))
+			})
+
+			It("compares the image of the selection to the baseline", func() {
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+
+			It("applies ignored regions relative to the image of the selection", func() {
+				writeBaseline(newImage(image.Rect(0, 0, 10, 10)))
+				matcher.IgnoreRegions = []image.Rectangle{image.Rect(5, 5, 6, 6)}
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+		})
+
+		Context("when the screenshot cannot be retrieved", func() {
+			It("returns an error", func() {
+				page.ScreenshotImageCall.Err = errors.New("some error")
+				_, err := matcher.Match(page)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the actual object is not a page or selection", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a page")
+				Expect(err).To(MatchError("MatchScreenshot matcher requires a Page or Selection.  Got:\n    <string>: not a page"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message with the diff percentage and written files", func() {
+			writeBaseline(newImage(image.Rect(0, 0, 10, 10)))
+			page.ScreenshotImageCall.ReturnImage = newImage(image.Rect(0, 0, 10, 10), image.Pt(1, 2))
+			matcher.Match(page)
+			message := matcher.FailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected screenshot to match baseline\n    " + filepath.Join(directory, "some-baseline.png")))
+			Expect(message).To(ContainSubstring("but found\n    1.00% of pixels differ (at most 0.00% may differ)"))
+			Expect(message).To(ContainSubstring("some-baseline.actual.png and " + filepath.Join(directory, "some-baseline.diff.png")))
+		})
+
+		Context("when the sizes differ", func() {
+			It("returns a failure message with both sizes", func() {
+				writeBaseline(newImage(image.Rect(0, 0, 10, 20)))
+				matcher.Match(page)
+				message := matcher.FailureMessage(page)
+				Expect(message).To(ContainSubstring("but found\n    a 10x10 screenshot for a 10x20 baseline"))
+			})
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			writeBaseline(newImage(image.Rect(0, 0, 10, 10)))
+			matcher.Match(page)
+			message := matcher.NegatedFailureMessage(page)
+			Expect(message).To(ContainSubstring("Expected screenshot not to match baseline\n    " + filepath.Join(directory, "some-baseline.png")))
+		})
+	})
+})
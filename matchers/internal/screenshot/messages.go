@@ -0,0 +1,13 @@
+package screenshot
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"strings"
+)
+
+func screenshotMessage(message, expected, actualValue string) string {
+	failureMessage := "Expected screenshot %s\n%s%s\nbut found\n%s%s"
+	actualValue = strings.Replace(actualValue, "\n", "\n"+format.Indent, -1)
+	return fmt.Sprintf(failureMessage, message, format.Indent, expected, format.Indent, actualValue)
+}
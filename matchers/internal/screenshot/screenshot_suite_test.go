@@ -0,0 +1,13 @@
+package screenshot_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestScreenshot(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Screenshot Suite")
+}
@@ -0,0 +1,47 @@
+package selection
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+)
+
+type ContainTextsMatcher struct {
+	ExpectedTexts []string
+	actualTexts   []string
+}
+
+func (m *ContainTextsMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Texts() ([]string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("ContainTexts matcher requires a Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actualTexts, err = actualSelection.Texts()
+	if err != nil {
+		return false, err
+	}
+
+	found := map[string]bool{}
+	for _, text := range m.actualTexts {
+		found[text] = true
+	}
+
+	for _, text := range m.ExpectedTexts {
+		if !found[text] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (m *ContainTextsMatcher) FailureMessage(actual interface{}) (message string) {
+	return selectorMessage(actual, "to contain texts", textsMessage(m.ExpectedTexts), textsMessage(m.actualTexts))
+}
+
+func (m *ContainTextsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return selectorMessage(actual, "not to contain texts", textsMessage(m.ExpectedTexts), textsMessage(m.actualTexts))
+}
@@ -0,0 +1,37 @@
+package selection
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"strconv"
+)
+
+type HaveCountMatcher struct {
+	ExpectedCount int
+	actualCount   int
+}
+
+func (m *HaveCountMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Count() (int, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveCount matcher requires a Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actualCount, err = actualSelection.Count()
+	if err != nil {
+		return false, err
+	}
+
+	return m.actualCount == m.ExpectedCount, nil
+}
+
+func (m *HaveCountMatcher) FailureMessage(actual interface{}) (message string) {
+	return selectorMessage(actual, "to have count", strconv.Itoa(m.ExpectedCount), strconv.Itoa(m.actualCount))
+}
+
+func (m *HaveCountMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return selectorMessage(actual, "not to have count", strconv.Itoa(m.ExpectedCount), strconv.Itoa(m.actualCount))
+}
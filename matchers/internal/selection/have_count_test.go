@@ -0,0 +1,77 @@
+package selection_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/selection"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveCountMatcher", func() {
+	var (
+		matcher   *HaveCountMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "CSS: li"
+		selection.CountCall.ReturnCount = 3
+		matcher = &HaveCountMatcher{ExpectedCount: 3}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			Context("when the selection refers to the expected number of elements", func() {
+				It("returns true", func() {
+					success, err := matcher.Match(selection)
+					Expect(success).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the selection refers to another number of elements", func() {
+				It("returns false", func() {
+					matcher.ExpectedCount = 2
+					success, _ := matcher.Match(selection)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when counting the elements fails", func() {
+				It("returns an error", func() {
+					selection.CountCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("HaveCount matcher requires a Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			matcher.ExpectedCount = 2
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: li' to have count\n    2"))
+			Expect(message).To(ContainSubstring("but found\n    3"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(ContainSubstring("Expected selection 'CSS: li' not to have count\n    3"))
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package selection
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+)
+
+type HaveTextsMatcher struct {
+	ExpectedTexts []string
+	actualTexts   []string
+}
+
+func (m *HaveTextsMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		Texts() ([]string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveTexts matcher requires a Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actualTexts, err = actualSelection.Texts()
+	if err != nil {
+		return false, err
+	}
+
+	if len(m.actualTexts) != len(m.ExpectedTexts) {
+		return false, nil
+	}
+
+	for index, text := range m.actualTexts {
+		if text != m.ExpectedTexts[index] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (m *HaveTextsMatcher) FailureMessage(actual interface{}) (message string) {
+	return selectorMessage(actual, "to have texts equaling", textsMessage(m.ExpectedTexts), textsMessage(m.actualTexts))
+}
+
+func (m *HaveTextsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return selectorMessage(actual, "not to have texts equaling", textsMessage(m.ExpectedTexts), textsMessage(m.actualTexts))
+}
@@ -0,0 +1,86 @@
+package selection_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/selection"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveTextsMatcher", func() {
+	var (
+		matcher   *HaveTextsMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "CSS: li"
+		selection.TextsCall.ReturnTexts = []string{"some text", "some other text"}
+		matcher = &HaveTextsMatcher{ExpectedTexts: []string{"some text", "some other text"}}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			Context("when the expected texts equal the actual texts in order", func() {
+				It("returns true", func() {
+					success, err := matcher.Match(selection)
+					Expect(success).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the actual texts are in a different order", func() {
+				It("returns false", func() {
+					matcher.ExpectedTexts = []string{"some other text", "some text"}
+					success, _ := matcher.Match(selection)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when there are more actual texts than expected", func() {
+				It("returns false", func() {
+					matcher.ExpectedTexts = []string{"some text"}
+					success, _ := matcher.Match(selection)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the texts fails", func() {
+				It("returns an error", func() {
+					selection.TextsCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("HaveTexts matcher requires a Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			matcher.ExpectedTexts = []string{"some text"}
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(ContainSubstring(`Expected selection 'CSS: li' to have texts equaling`))
+			Expect(message).To(ContainSubstring(`["some text"]`))
+			Expect(message).To(ContainSubstring(`["some text", "some other text"]`))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(ContainSubstring(`Expected selection 'CSS: li' not to have texts equaling`))
+		})
+	})
+})
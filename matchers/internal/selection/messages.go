@@ -3,6 +3,8 @@ package selection
 import (
 	"fmt"
 	"github.com/onsi/gomega/format"
+	"strconv"
+	"strings"
 )
 
 func selectorMessage(actual interface{}, message, expected, actualValue string) string {
@@ -19,3 +21,11 @@ func booleanSelectorMessage(actual interface{}, message string) string {
 	failureMessage := "Expected selection '%s' %s"
 	return fmt.Sprintf(failureMessage, actual, message)
 }
+
+func textsMessage(texts []string) string {
+	quoted := make([]string, 0, len(texts))
+	for _, text := range texts {
+		quoted = append(quoted, strconv.Quote(text))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
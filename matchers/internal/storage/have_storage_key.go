@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+	"strings"
+)
+
+type HaveStorageKeyMatcher struct {
+	ExpectedKey string
+	actualKeys  []string
+}
+
+func (m *HaveStorageKeyMatcher) Match(actual interface{}) (success bool, err error) {
+	actualStorage, ok := actual.(interface {
+		Keys() ([]string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveStorageKey matcher requires a Storage.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actualKeys, err = actualStorage.Keys()
+	if err != nil {
+		return false, err
+	}
+
+	for _, key := range m.actualKeys {
+		if key == m.ExpectedKey {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *HaveStorageKeyMatcher) FailureMessage(_ interface{}) (message string) {
+	return storageMessage("to have key", m.ExpectedKey, strings.Join(m.actualKeys, ", "))
+}
+
+func (m *HaveStorageKeyMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return storageMessage("not to have key", m.ExpectedKey, strings.Join(m.actualKeys, ", "))
+}
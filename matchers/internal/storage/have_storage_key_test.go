@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveStorageKeyMatcher", func() {
+	var (
+		matcher *HaveStorageKeyMatcher
+		storage *mocks.Storage
+	)
+
+	BeforeEach(func() {
+		storage = &mocks.Storage{}
+		storage.KeysCall.ReturnKeys = []string{"some-key", "some-other-key"}
+		matcher = &HaveStorageKeyMatcher{ExpectedKey: "some-key"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a storage", func() {
+			Context("when the storage has the expected key", func() {
+				It("returns true", func() {
+					success, _ := matcher.Match(storage)
+					Expect(success).To(BeTrue())
+				})
+			})
+
+			Context("when the storage does not have the expected key", func() {
+				It("returns false", func() {
+					matcher.ExpectedKey = "some-missing-key"
+					success, _ := matcher.Match(storage)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the keys fails", func() {
+				It("returns an error", func() {
+					storage.KeysCall.Err = errors.New("some error")
+					_, err := matcher.Match(storage)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a storage", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a storage")
+				Expect(err).To(MatchError("HaveStorageKey matcher requires a Storage.  Got:\n    <string>: not a storage"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			matcher.ExpectedKey = "some-missing-key"
+			matcher.Match(storage)
+			message := matcher.FailureMessage(storage)
+			Expect(message).To(ContainSubstring("Expected storage to have key\n    some-missing-key"))
+			Expect(message).To(ContainSubstring("but found\n    some-key, some-other-key"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(storage)
+			message := matcher.NegatedFailureMessage(storage)
+			Expect(message).To(ContainSubstring("Expected storage not to have key\n    some-key"))
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+)
+
+type HaveStorageValueMatcher struct {
+	ExpectedKey   string
+	ExpectedValue string
+	actualValue   string
+	found         bool
+}
+
+func (m *HaveStorageValueMatcher) Match(actual interface{}) (success bool, err error) {
+	actualStorage, ok := actual.(interface {
+		Get(key string) (string, error)
+		Keys() ([]string, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveStorageValue matcher requires a Storage.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	keys, err := actualStorage.Keys()
+	if err != nil {
+		return false, err
+	}
+
+	m.found = false
+	for _, key := range keys {
+		if key == m.ExpectedKey {
+			m.found = true
+		}
+	}
+
+	if !m.found {
+		return false, nil
+	}
+
+	m.actualValue, err = actualStorage.Get(m.ExpectedKey)
+	if err != nil {
+		return false, err
+	}
+
+	return m.actualValue == m.ExpectedValue, nil
+}
+
+func (m *HaveStorageValueMatcher) FailureMessage(_ interface{}) (message string) {
+	return storageMessage("to have value", m.expected(), m.actual())
+}
+
+func (m *HaveStorageValueMatcher) NegatedFailureMessage(_ interface{}) (message string) {
+	return storageMessage("not to have value", m.expected(), m.actual())
+}
+
+func (m *HaveStorageValueMatcher) expected() string {
+	return m.ExpectedKey + "=" + m.ExpectedValue
+}
+
+func (m *HaveStorageValueMatcher) actual() string {
+	if !m.found {
+		return "no value for " + m.ExpectedKey
+	}
+	return m.ExpectedKey + "=" + m.actualValue
+}
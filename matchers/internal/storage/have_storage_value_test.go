@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"errors"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	. "github.com/sclevine/agouti/matchers/internal/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveStorageValueMatcher", func() {
+	var (
+		matcher *HaveStorageValueMatcher
+		storage *mocks.Storage
+	)
+
+	BeforeEach(func() {
+		storage = &mocks.Storage{}
+		storage.KeysCall.ReturnKeys = []string{"some-key"}
+		storage.GetCall.ReturnValue = "some value"
+		matcher = &HaveStorageValueMatcher{ExpectedKey: "some-key", ExpectedValue: "some value"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a storage", func() {
+			It("requests the value for the expected key", func() {
+				matcher.Match(storage)
+				Expect(storage.GetCall.Key).To(Equal("some-key"))
+			})
+
+			Context("when the storage has the expected value for the key", func() {
+				It("returns true", func() {
+					success, _ := matcher.Match(storage)
+					Expect(success).To(BeTrue())
+				})
+			})
+
+			Context("when the storage has another value for the key", func() {
+				It("returns false", func() {
+					matcher.ExpectedValue = "some other value"
+					success, _ := matcher.Match(storage)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when the storage does not have the key", func() {
+				It("returns false", func() {
+					matcher.ExpectedKey = "some-missing-key"
+					matcher.ExpectedValue = ""
+					storage.GetCall.ReturnValue = ""
+					success, _ := matcher.Match(storage)
+					Expect(success).To(BeFalse())
+				})
+			})
+
+			Context("when retrieving the value fails", func() {
+				It("returns an error", func() {
+					storage.GetCall.Err = errors.New("some error")
+					_, err := matcher.Match(storage)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a storage", func() {
+			It("returns an error", func() {
+				_, err := matcher.Match("not a storage")
+				Expect(err).To(MatchError("HaveStorageValue matcher requires a Storage.  Got:\n    <string>: not a storage"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("returns a failure message", func() {
+			matcher.ExpectedValue = "some other value"
+			matcher.Match(storage)
+			message := matcher.FailureMessage(storage)
+			Expect(message).To(ContainSubstring("Expected storage to have value\n    some-key=some other value"))
+			Expect(message).To(ContainSubstring("but found\n    some-key=some value"))
+		})
+
+		Context("when the key is not stored", func() {
+			It("returns a failure message describing the missing value", func() {
+				matcher.ExpectedKey = "some-missing-key"
+				matcher.Match(storage)
+				message := matcher.FailureMessage(storage)
+				Expect(message).To(ContainSubstring("but found\n    no value for some-missing-key"))
+			})
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("returns a negated failure message", func() {
+			matcher.Match(storage)
+			message := matcher.NegatedFailureMessage(storage)
+			Expect(message).To(ContainSubstring("Expected storage not to have value\n    some-key=some value"))
+		})
+	})
+})
@@ -0,0 +1,11 @@
+package storage
+
+import (
+	"fmt"
+	"github.com/onsi/gomega/format"
+)
+
+func storageMessage(message, expected, actualValue string) string {
+	failureMessage := "Expected storage %s\n%s%s\nbut found\n%s%s"
+	return fmt.Sprintf(failureMessage, message, format.Indent, expected, format.Indent, actualValue)
+}
@@ -2,6 +2,7 @@ package matchers
 
 import (
 	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/core"
 	"github.com/sclevine/agouti/matchers/internal/page"
 )
 
@@ -10,3 +11,40 @@ import (
 func HaveTitle(title string) types.GomegaMatcher {
 	return &page.HaveTitleMatcher{ExpectedTitle: title}
 }
+
+// HaveWindowCount passes when the provided page has exactly the expected
+// number of open windows.
+func HaveWindowCount(count int) types.GomegaMatcher {
+	return &page.HaveWindowCountMatcher{ExpectedCount: count}
+}
+
+// HavePopupText passes when the expected text is equivalent to the
+// text of the open alert, confirm or prompt popup on the provided page.
+func HavePopupText(text string) types.GomegaMatcher {
+	return &page.HavePopupTextMatcher{ExpectedText: text}
+}
+
+// HaveNoPopup passes when the provided page has no open alert, confirm or prompt popup.
+func HaveNoPopup() types.GomegaMatcher {
+	return &page.HaveNoPopupMatcher{NoPopupErr: core.ErrNoSuchAlert}
+}
+
+// HaveCookie passes when the provided page has a cookie with the expected
+// name and value.
+func HaveCookie(name, value string) types.GomegaMatcher {
+	return &pageMatcher{&page.HaveCookieMatcher{ExpectedName: name, ExpectedValue: value}}
+}
+
+// HaveLoggedError passes when the browser log of the provided page has an
+// entry at the SEVERE level, such as an uncaught exception or console.error.
+// Entries are consumed when read, so each entry is only matched once.
+func HaveLoggedError() types.GomegaMatcher {
+	return &pageMatcher{&page.HaveLoggedErrorMatcher{}}
+}
+
+// HaveLoggedInfo passes when the browser log of the provided page has an
+// entry at the INFO level, such as console.log, that contains the expected text.
+// Entries are consumed when read, so each entry is only matched once.
+func HaveLoggedInfo(text string) types.GomegaMatcher {
+	return &pageMatcher{&page.HaveLoggedInfoMatcher{ExpectedText: text}}
+}
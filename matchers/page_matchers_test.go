@@ -1,10 +1,14 @@
 package matchers_test
 
 import (
+	"fmt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/core"
 	. "github.com/sclevine/agouti/matchers"
 	"github.com/sclevine/agouti/matchers/internal/mocks"
+	pagematcher "github.com/sclevine/agouti/matchers/internal/page"
+	"net/http"
 )
 
 var _ = Describe("Page Matchers", func() {
@@ -21,4 +25,53 @@ var _ = Describe("Page Matchers", func() {
 			Expect(page).NotTo(HaveTitle("Some Other Title"))
 		})
 	})
+
+	Describe("#HaveWindowCount", func() {
+		It("calls the page#HaveWindowCount matcher", func() {
+			page.WindowsCall.ReturnWindows = []string{"some-window"}
+			Expect(page).To(HaveWindowCount(1))
+			Expect(page).NotTo(HaveWindowCount(2))
+		})
+	})
+
+	Describe("#HavePopupText", func() {
+		It("calls the page#HavePopupText matcher", func() {
+			page.PopupTextCall.ReturnText = "some text"
+			Expect(page).To(HavePopupText("some text"))
+			Expect(page).NotTo(HavePopupText("some other text"))
+		})
+	})
+
+	Describe("#HaveNoPopup", func() {
+		It("calls the page#HaveNoPopup matcher", func() {
+			Expect(page).NotTo(HaveNoPopup())
+			page.PopupTextCall.Err = fmt.Errorf("failed to retrieve popup text: %w", core.ErrNoSuchAlert)
+			Expect(page).To(HaveNoPopup())
+		})
+	})
+
+	Describe("#HaveCookie", func() {
+		It("calls the page#HaveCookie matcher", func() {
+			page.GetCookiesCall.ReturnCookies = []*http.Cookie{{Name: "some-name", Value: "some-value"}}
+			Expect(page).To(HaveCookie("some-name", "some-value"))
+			Expect(page).NotTo(HaveCookie("some-name", "some-other-value"))
+		})
+	})
+
+	Describe("#HaveLoggedError", func() {
+		It("calls the page#HaveLoggedError matcher", func() {
+			page.ReadLogsCall.ReturnLogs = []pagematcher.Log{{Message: "some error", Level: "SEVERE"}}
+			Expect(page).To(HaveLoggedError())
+			page.ReadLogsCall.ReturnLogs = []pagematcher.Log{{Message: "some info", Level: "INFO"}}
+			Expect(page).NotTo(HaveLoggedError())
+		})
+	})
+
+	Describe("#HaveLoggedInfo", func() {
+		It("calls the page#HaveLoggedInfo matcher", func() {
+			page.ReadLogsCall.ReturnLogs = []pagematcher.Log{{Message: "some info", Level: "INFO"}}
+			Expect(page).To(HaveLoggedInfo("info"))
+			Expect(page).NotTo(HaveLoggedInfo("missing"))
+		})
+	})
 })
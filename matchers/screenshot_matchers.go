@@ -0,0 +1,76 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/core"
+	"github.com/sclevine/agouti/matchers/internal/screenshot"
+	"image"
+	"os"
+)
+
+// UpdateBaselinesEnv is the environment variable that, when set to any
+// non-empty value, causes MatchScreenshot to write the screenshot as the new
+// baseline instead of comparing it.
+const UpdateBaselinesEnv = screenshot.UpdateEnv
+
+// DefaultBaselineDirectory is the directory in which MatchScreenshot stores
+// baselines, unless BaselineDirectory is provided.
+const DefaultBaselineDirectory = "screenshots"
+
+// ScreenshotOption configures MatchScreenshot.
+type ScreenshotOption func(*screenshot.MatchScreenshotMatcher)
+
+// MatchScreenshot passes when a screenshot of the provided page or selection
+// matches the baseline PNG image named baselineName. By default, every pixel must match.
+// When the screenshot does not match, the screenshot and an image highlighting
+// the changed pixels in red are written next to the baseline, as
+// <baselineName>.actual.png and <baselineName>.diff.png.
+// New baselines are written when UpdateBaselinesEnv is set.
+func MatchScreenshot(baselineName string, options ...ScreenshotOption) types.GomegaMatcher {
+	matcher := &screenshot.MatchScreenshotMatcher{
+		BaselineName: baselineName,
+		Directory:    DefaultBaselineDirectory,
+		Update:       os.Getenv(UpdateBaselinesEnv) != "",
+	}
+	for _, option := range options {
+		option(matcher)
+	}
+	return &pageMatcher{matcher}
+}
+
+// BaselineDirectory stores baselines in directory instead of DefaultBaselineDirectory.
+func BaselineDirectory(directory string) ScreenshotOption {
+	return func(m *screenshot.MatchScreenshotMatcher) {
+		m.Directory = directory
+	}
+}
+
+// PixelTolerance allows each color channel of a pixel to differ from the
+// baseline by up to tolerance (out of 255) before the pixel is considered changed.
+func PixelTolerance(tolerance uint8) ScreenshotOption {
+	return func(m *screenshot.MatchScreenshotMatcher) {
+		m.Tolerance = tolerance
+	}
+}
+
+// MaxDiffPercent allows up to percent (0-100) of the compared pixels to change.
+func MaxDiffPercent(percent float64) ScreenshotOption {
+	return func(m *screenshot.MatchScreenshotMatcher) {
+		m.MaxDiffPercent = percent
+	}
+}
+
+// IgnoreSelection excludes the element referred to by selection from the comparison.
+func IgnoreSelection(selection core.Selection) ScreenshotOption {
+	return func(m *screenshot.MatchScreenshotMatcher) {
+		m.IgnoreSelections = append(m.IgnoreSelections, selection)
+	}
+}
+
+// IgnoreRegion excludes region, in pixels relative to the top-left corner of
+// the screenshot, from the comparison.
+func IgnoreRegion(region image.Rectangle) ScreenshotOption {
+	return func(m *screenshot.MatchScreenshotMatcher) {
+		m.IgnoreRegions = append(m.IgnoreRegions, region)
+	}
+}
@@ -0,0 +1,56 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+	"image"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Screenshot Matchers", func() {
+	var (
+		page      *mocks.Page
+		directory string
+	)
+
+	BeforeEach(func() {
+		directory, _ = ioutil.TempDir("", "baselines")
+		page = &mocks.Page{}
+		page.ScreenshotImageCall.ReturnImage = image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	Describe("#MatchScreenshot", func() {
+		It("calls the screenshot#MatchScreenshot matcher", func() {
+			os.Setenv(UpdateBaselinesEnv, "true")
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory)))
+			os.Unsetenv(UpdateBaselinesEnv)
+			Expect(filepath.Join(directory, "some-baseline.png")).To(BeAnExistingFile())
+
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory)))
+			page.ScreenshotImageCall.ReturnImage = image.NewNRGBA(image.Rect(0, 0, 10, 20))
+			Expect(page).NotTo(MatchScreenshot("some-baseline", BaselineDirectory(directory)))
+		})
+
+		It("applies the comparison options", func() {
+			os.Setenv(UpdateBaselinesEnv, "true")
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory)))
+			os.Unsetenv(UpdateBaselinesEnv)
+
+			changed := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+			changed.Pix[0] = 10
+			page.ScreenshotImageCall.ReturnImage = changed
+			Expect(page).NotTo(MatchScreenshot("some-baseline", BaselineDirectory(directory)))
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory), PixelTolerance(10)))
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory), MaxDiffPercent(1)))
+			Expect(page).To(MatchScreenshot("some-baseline", BaselineDirectory(directory), IgnoreRegion(image.Rect(0, 0, 1, 1))))
+		})
+	})
+})
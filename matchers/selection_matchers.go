@@ -53,3 +53,21 @@ func BeFound() types.GomegaMatcher {
 func EqualElement(comparable interface{}) types.GomegaMatcher {
 	return &selection.EqualElementMatcher{ExpectedSelection: comparable}
 }
+
+// HaveTexts passes when the texts of the elements referred to by the provided
+// selection equal the expected texts, in order.
+func HaveTexts(texts ...string) types.GomegaMatcher {
+	return &selection.HaveTextsMatcher{ExpectedTexts: texts}
+}
+
+// ContainTexts passes when each of the expected texts equals the text of at
+// least one of the elements referred to by the provided selection, in any order.
+func ContainTexts(texts ...string) types.GomegaMatcher {
+	return &selection.ContainTextsMatcher{ExpectedTexts: texts}
+}
+
+// HaveCount passes when the provided selection refers to exactly the expected
+// number of elements.
+func HaveCount(count int) types.GomegaMatcher {
+	return &selection.HaveCountMatcher{ExpectedCount: count}
+}
@@ -81,4 +81,28 @@ var _ = Describe("Selection Matchers", func() {
 			Expect(selection).NotTo(EqualElement(selection))
 		})
 	})
+
+	Describe("#HaveTexts", func() {
+		It("calls the selection#HaveTexts matcher", func() {
+			selection.TextsCall.ReturnTexts = []string{"some text", "some other text"}
+			Expect(selection).To(HaveTexts("some text", "some other text"))
+			Expect(selection).NotTo(HaveTexts("some text"))
+		})
+	})
+
+	Describe("#ContainTexts", func() {
+		It("calls the selection#ContainTexts matcher", func() {
+			selection.TextsCall.ReturnTexts = []string{"some text", "some other text"}
+			Expect(selection).To(ContainTexts("some other text"))
+			Expect(selection).NotTo(ContainTexts("missing text"))
+		})
+	})
+
+	Describe("#HaveCount", func() {
+		It("calls the selection#HaveCount matcher", func() {
+			selection.CountCall.ReturnCount = 2
+			Expect(selection).To(HaveCount(2))
+			Expect(selection).NotTo(HaveCount(3))
+		})
+	})
 })
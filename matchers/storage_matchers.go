@@ -0,0 +1,18 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"github.com/sclevine/agouti/matchers/internal/storage"
+)
+
+// HaveStorageKey passes when the provided localStorage or sessionStorage
+// has a value stored for the expected key.
+func HaveStorageKey(key string) types.GomegaMatcher {
+	return &storage.HaveStorageKeyMatcher{ExpectedKey: key}
+}
+
+// HaveStorageValue passes when the provided localStorage or sessionStorage
+// has the expected value stored for the expected key.
+func HaveStorageValue(key, value string) types.GomegaMatcher {
+	return &storage.HaveStorageValueMatcher{ExpectedKey: key, ExpectedValue: value}
+}
@@ -0,0 +1,33 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("Storage Matchers", func() {
+	var storage *mocks.Storage
+
+	BeforeEach(func() {
+		storage = &mocks.Storage{}
+	})
+
+	Describe("#HaveStorageKey", func() {
+		It("calls the storage#HaveStorageKey matcher", func() {
+			storage.KeysCall.ReturnKeys = []string{"some-key"}
+			Expect(storage).To(HaveStorageKey("some-key"))
+			Expect(storage).NotTo(HaveStorageKey("some-other-key"))
+		})
+	})
+
+	Describe("#HaveStorageValue", func() {
+		It("calls the storage#HaveStorageValue matcher", func() {
+			storage.KeysCall.ReturnKeys = []string{"some-key"}
+			storage.GetCall.ReturnValue = "some value"
+			Expect(storage).To(HaveStorageValue("some-key", "some value"))
+			Expect(storage).NotTo(HaveStorageValue("some-key", "some other value"))
+		})
+	})
+})